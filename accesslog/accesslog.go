@@ -0,0 +1,88 @@
+// Package accesslog writes a structured JSON line per HTTP request.
+package accesslog
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	resultCountKey  = "accesslog.resultCount"
+	// Longer request IDs of clients are replaced
+	maxRequestIDLength = 128
+)
+
+// NewLogger returns a JSON logger writing to stdout or, if toFile is set, to the file in the logs directory.
+// The file is rotated when it reaches 100 MB and the last 10 rotated files are kept compressed.
+func NewLogger(toFile bool, filename string) *slog.Logger {
+	var w io.Writer = os.Stdout
+	if toFile {
+		w = &lumberjack.Logger{
+			Filename:   filepath.Join("logs", filename),
+			MaxSize:    100,
+			MaxBackups: 10,
+			Compress:   true,
+		}
+	}
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
+
+// SetResultCount records the number of offers returned by the request
+func SetResultCount(c *gin.Context, count int) {
+	c.Set(resultCountKey, count)
+}
+
+// validRequestID reports whether a request ID of a client can be logged and returned. It allows
+// letters, digits and - _ . : up to maxRequestIDLength characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		valid := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || strings.ContainsRune("-_.:", r)
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware logs every request when it is done. The request ID is taken from the X-Request-ID
+// header if it is valid, otherwise generated, and returned in the same header.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		attrs := []slog.Attr{
+			slog.String("requestID", requestID),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("query", c.Request.URL.RawQuery),
+			slog.Int("status", c.Writer.Status()),
+			slog.Float64("latencyMs", float64(time.Since(start).Microseconds())/1000),
+		}
+		if count, ok := c.Get(resultCountKey); ok {
+			attrs = append(attrs, slog.Any("resultCount", count))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+		logger.LogAttrs(c.Request.Context(), slog.LevelInfo, "request", attrs...)
+	}
+}
@@ -0,0 +1,79 @@
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	r := gin.New()
+	r.Use(Middleware(slog.New(slog.NewJSONHandler(&logs, nil))))
+	r.GET("/api/offers", func(c *gin.Context) {
+		SetResultCount(c, 3)
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		requestID string
+		echoed    bool
+	}{
+		{requestID: "client-id_1.2:3", echoed: true},
+		{requestID: ""},
+		{requestID: "forged\" id"},
+		{requestID: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/offers?regionID=7", nil)
+		if tt.requestID != "" {
+			req.Header.Set(RequestIDHeader, tt.requestID)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get(RequestIDHeader)
+		if tt.echoed && got != tt.requestID {
+			t.Errorf("request ID %q returned as %q", tt.requestID, got)
+		}
+		if !tt.echoed {
+			if _, err := uuid.Parse(got); err != nil {
+				t.Errorf("request ID %q is replaced by %q, want a generated UUID", tt.requestID, got)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(&logs)
+	lines := 0
+	for ; scanner.Scan(); lines++ {
+		var entry struct {
+			Msg         string   `json:"msg"`
+			RequestID   string   `json:"requestID"`
+			Method      string   `json:"method"`
+			Path        string   `json:"path"`
+			Query       string   `json:"query"`
+			Status      int      `json:"status"`
+			LatencyMs   *float64 `json:"latencyMs"`
+			ResultCount int      `json:"resultCount"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %d: %v", lines+1, err)
+		}
+		if entry.Msg != "request" || entry.RequestID == "" || entry.Method != http.MethodGet || entry.Path != "/api/offers" ||
+			entry.Query != "regionID=7" || entry.Status != http.StatusOK || entry.LatencyMs == nil || entry.ResultCount != 3 {
+			t.Errorf("line %d: %s", lines+1, scanner.Bytes())
+		}
+	}
+	if lines != len(tests) {
+		t.Errorf("%d lines for %d requests", lines, len(tests))
+	}
+}
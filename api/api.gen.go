@@ -0,0 +1,551 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for GetOffersParamsSortOrder.
+const (
+	PriceAsc  GetOffersParamsSortOrder = "price-asc"
+	PriceDesc GetOffersParamsSortOrder = "price-desc"
+)
+
+// CarTypeCount defines model for CarTypeCount.
+type CarTypeCount struct {
+	// Family The number of offers with the car type family
+	Family uint32 `json:"family"`
+
+	// Luxury The number of offers with the car type luxury
+	Luxury uint32 `json:"luxury"`
+
+	// Small The number of offers with the car type small
+	Small uint32 `json:"small"`
+
+	// Sports The number of offers with the car type sports
+	Sports uint32 `json:"sports"`
+}
+
+// Condition Either a combination of conditions or a comparison of a field
+type Condition struct {
+	And   *[]Condition   `json:"and,omitempty"`
+	Eq    *interface{}   `json:"eq,omitempty"`
+	Field *string        `json:"field,omitempty"`
+	Gt    *int64         `json:"gt,omitempty"`
+	Gte   *int64         `json:"gte,omitempty"`
+	In    *[]interface{} `json:"in,omitempty"`
+	Lt    *int64         `json:"lt,omitempty"`
+	Lte   *int64         `json:"lte,omitempty"`
+
+	// Not Either a combination of conditions or a comparison of a field
+	Not *Condition   `json:"not,omitempty"`
+	Or  *[]Condition `json:"or,omitempty"`
+}
+
+// FreeKilometerRange defines model for FreeKilometerRange.
+type FreeKilometerRange struct {
+	// Count The number of offers in this free kilometer range
+	Count uint32 `json:"count"`
+
+	// End The end of the free kilometer range
+	End uint16 `json:"end"`
+
+	// Start The start of the free kilometer range
+	Start uint16 `json:"start"`
+}
+
+// HistogramRange defines model for HistogramRange.
+type HistogramRange struct {
+	// Count The number of offers in this bucket
+	Count int64 `json:"count"`
+
+	// End The exclusive end of the bucket
+	End int64 `json:"end"`
+
+	// Start The inclusive start of the bucket
+	Start int64 `json:"start"`
+}
+
+// Offer defines model for Offer.
+type Offer struct {
+	// ID The unique identifier of the offer
+	ID openapi_types.UUID `json:"ID"`
+
+	// CarType The car types the offer belongs to
+	CarType string `json:"carType"`
+
+	// Data Additional data for the offer, that is not used for filtering. For simplicity, this is just a base64 encoded 256 Byte array
+	Data []byte `json:"data"`
+
+	// EndDate The end date of the offer in ms since UNIX epoch
+	EndDate int64 `json:"endDate"`
+
+	// FreeKilometers The number of kilometers included for free
+	FreeKilometers uint16 `json:"freeKilometers"`
+
+	// HasVollkasko Whether the offer has Vollkasko
+	HasVollkasko bool `json:"hasVollkasko"`
+
+	// MostSpecificRegionID The id of the most specific region the offer belongs to. This is always a leaf node.
+	MostSpecificRegionID int32 `json:"mostSpecificRegionID"`
+
+	// NumberSeats The number of seats the car has
+	NumberSeats uint8 `json:"numberSeats"`
+
+	// Price The price in cents
+	Price uint16 `json:"price"`
+
+	// StartDate The start date of the offer in ms since UNIX epoch
+	StartDate int64 `json:"startDate"`
+}
+
+// PageMeta The page of the offers
+type PageMeta struct {
+	// HasNext Whether there are offers on the next page
+	HasNext  bool  `json:"hasNext"`
+	Page     int64 `json:"page"`
+	PageSize int64 `json:"pageSize"`
+
+	// Total The number of offers matching all filters
+	Total int64 `json:"total"`
+}
+
+// PriceRange defines model for PriceRange.
+type PriceRange struct {
+	// Count The number of offers in this price range
+	Count uint32 `json:"count"`
+
+	// End The end of the price range in cent
+	End uint16 `json:"end"`
+
+	// Start The start of the price range in cent
+	Start uint16 `json:"start"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	CarTypeCounts CarTypeCount `json:"carTypeCounts"`
+
+	// FreeKilometerRange Buckets holding information of the number of offers within a specific free kilometer range. The results only includes buckets with at least one offer in that range sorted by the start ascendingly. Bucket starts and ends are a multiple of the width.
+	FreeKilometerRange []FreeKilometerRange `json:"freeKilometerRange"`
+
+	// Meta The page of the offers
+	Meta *PageMeta `json:"meta,omitempty"`
+
+	// Offers All offers matching the query, with their IDs and additional data.
+	Offers []SearchResultOffer `json:"offers"`
+
+	// PricePerDayRanges Buckets of the price per day, only if requested
+	PricePerDayRanges *[]HistogramRange `json:"pricePerDayRanges,omitempty"`
+
+	// PriceRanges Buckets holding information of the number of offers within a specific price range. The results only includes buckets with at least one offer in that range sorted by start ascendingly. Bucket starts and ends are a multiple of the width.
+	PriceRanges []PriceRange `json:"priceRanges"`
+
+	// SeatsCount Buckets holding information of the number of offers with a specific seat count. The results only includes entries with at least one offer with that seats count sorted by numberSeats ascendingly.
+	SeatsCount []SeatsCount `json:"seatsCount"`
+
+	// StartDateRanges Calendar buckets of the start date, only if requested
+	StartDateRanges *[]HistogramRange `json:"startDateRanges,omitempty"`
+	Stats           *StatsSummary     `json:"stats,omitempty"`
+
+	// SubRegionCounts The number of offers in each direct sub region, only if requested
+	SubRegionCounts *[]SubRegionCount `json:"subRegionCounts,omitempty"`
+	VollkaskoCount  VollkaskoCount    `json:"vollkaskoCount"`
+}
+
+// SearchResultOffer defines model for SearchResultOffer.
+type SearchResultOffer struct {
+	// ID The unique identifier of the offer
+	ID openapi_types.UUID `json:"ID"`
+
+	// Data Additional data of the offer, that is not used for filtering. For simplicity, this is just a base64 encoded 256 Byte array
+	Data []byte `json:"data"`
+}
+
+// SeatsCount defines model for SeatsCount.
+type SeatsCount struct {
+	// Count The number of offers with the given number of seats
+	Count uint32 `json:"count"`
+
+	// NumberSeats The number of seats the cars have
+	NumberSeats uint8 `json:"numberSeats"`
+}
+
+// Stats defines model for Stats.
+type Stats struct {
+	Count  int64   `json:"count"`
+	Max    int64   `json:"max"`
+	Mean   float32 `json:"mean"`
+	Median int64   `json:"median"`
+	Min    int64   `json:"min"`
+	P90    int64   `json:"p90"`
+}
+
+// StatsSummary defines model for StatsSummary.
+type StatsSummary struct {
+	FreeKilometers Stats `json:"freeKilometers"`
+	Price          Stats `json:"price"`
+}
+
+// SubRegionCount defines model for SubRegionCount.
+type SubRegionCount struct {
+	Count    int64  `json:"count"`
+	Name     string `json:"name"`
+	RegionID int32  `json:"regionID"`
+}
+
+// VollkaskoCount defines model for VollkaskoCount.
+type VollkaskoCount struct {
+	// FalseCount The number of offers without Vollkasko
+	FalseCount uint32 `json:"falseCount"`
+
+	// TrueCount The number of offers with vollkasko
+	TrueCount uint32 `json:"trueCount"`
+}
+
+// GetOffersParams defines parameters for GetOffers.
+type GetOffersParams struct {
+	// RegionID Region ID for which offers are returned. This includes offers from all subregions of this regionID. See the 'Introduction' Section in the documentation.
+	RegionID int32 `form:"regionID" json:"regionID"`
+
+	// TimeRangeStart Timestamp (ms since UNIX epoch) from when offers are considered (inclusive)
+	TimeRangeStart int64 `form:"timeRangeStart" json:"timeRangeStart"`
+
+	// TimeRangeEnd Timestamp (ms since UNIX epoch) until when offers are considered (inclusive)
+	TimeRangeEnd int64 `form:"timeRangeEnd" json:"timeRangeEnd"`
+
+	// NumberDays The number of full days (24h) the car is available within the rangeStart and rangeEnd
+	NumberDays uint16 `form:"numberDays" json:"numberDays"`
+
+	// SortOrder The order in which offers are returned. When two offers have the same price, the one with the lexicographical smaller ID is returned first (for both sort orders).
+	SortOrder GetOffersParamsSortOrder `form:"sortOrder" json:"sortOrder"`
+
+	// Page The page number from pagination
+	Page uint32 `form:"page" json:"page"`
+
+	// PageSize The number of offers per page
+	PageSize uint32 `form:"pageSize" json:"pageSize"`
+
+	// PriceRangeWidth The width of the price range blocks in cents
+	PriceRangeWidth uint32 `form:"priceRangeWidth" json:"priceRangeWidth"`
+
+	// MinFreeKilometerWidth The width of the min free kilometer in km
+	MinFreeKilometerWidth uint32 `form:"minFreeKilometerWidth" json:"minFreeKilometerWidth"`
+
+	// MinNumberSeats How many seats the returned cars each have
+	MinNumberSeats *uint8 `form:"minNumberSeats,omitempty" json:"minNumberSeats,omitempty"`
+
+	// MinPrice Minimum (inclusive) price the offers have in cent
+	MinPrice *uint16 `form:"minPrice,omitempty" json:"minPrice,omitempty"`
+
+	// MaxPrice Maximum (exclusive) price the offers have in cent
+	MaxPrice *uint16 `form:"maxPrice,omitempty" json:"maxPrice,omitempty"`
+
+	// CarType The car type. Offers of any of the given car types are returned, multiple car types are given as repeated or comma separated parameters.
+	CarType *[]string `form:"carType,omitempty" json:"carType,omitempty"`
+
+	// OnlyVollkasko Whether only offers with vollkasko are returned
+	OnlyVollkasko *bool `form:"onlyVollkasko,omitempty" json:"onlyVollkasko,omitempty"`
+
+	// MinFreeKilometer Minimum number of kilometers that the offer includes for free
+	MinFreeKilometer *uint16 `form:"minFreeKilometer,omitempty" json:"minFreeKilometer,omitempty"`
+}
+
+// GetOffersParamsSortOrder defines parameters for GetOffers.
+type GetOffersParamsSortOrder string
+
+// CreateOffersJSONBody defines parameters for CreateOffers.
+type CreateOffersJSONBody struct {
+	Offers *[]Offer `json:"offers,omitempty"`
+}
+
+// CreateOffersJSONRequestBody defines body for CreateOffers for application/json ContentType.
+type CreateOffersJSONRequestBody CreateOffersJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Clean up data
+	// (DELETE /api/offers)
+	CleanupData(c *gin.Context)
+	// Get offers
+	// (GET /api/offers)
+	GetOffers(c *gin.Context, params GetOffersParams)
+	// Create offers
+	// (POST /api/offers)
+	CreateOffers(c *gin.Context)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// CleanupData operation middleware
+func (siw *ServerInterfaceWrapper) CleanupData(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CleanupData(c)
+}
+
+// GetOffers operation middleware
+func (siw *ServerInterfaceWrapper) GetOffers(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOffersParams
+
+	// ------------- Required query parameter "regionID" -------------
+
+	if paramValue := c.Query("regionID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument regionID is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "regionID", c.Request.URL.Query(), &params.RegionID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter regionID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "timeRangeStart" -------------
+
+	if paramValue := c.Query("timeRangeStart"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument timeRangeStart is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "timeRangeStart", c.Request.URL.Query(), &params.TimeRangeStart)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timeRangeStart: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "timeRangeEnd" -------------
+
+	if paramValue := c.Query("timeRangeEnd"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument timeRangeEnd is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "timeRangeEnd", c.Request.URL.Query(), &params.TimeRangeEnd)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timeRangeEnd: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "numberDays" -------------
+
+	if paramValue := c.Query("numberDays"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument numberDays is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "numberDays", c.Request.URL.Query(), &params.NumberDays)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter numberDays: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "sortOrder" -------------
+
+	if paramValue := c.Query("sortOrder"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument sortOrder is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sortOrder", c.Request.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortOrder: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page" -------------
+
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "pageSize" -------------
+
+	if paramValue := c.Query("pageSize"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument pageSize is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "priceRangeWidth" -------------
+
+	if paramValue := c.Query("priceRangeWidth"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument priceRangeWidth is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "priceRangeWidth", c.Request.URL.Query(), &params.PriceRangeWidth)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter priceRangeWidth: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "minFreeKilometerWidth" -------------
+
+	if paramValue := c.Query("minFreeKilometerWidth"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument minFreeKilometerWidth is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "minFreeKilometerWidth", c.Request.URL.Query(), &params.MinFreeKilometerWidth)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minFreeKilometerWidth: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minNumberSeats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minNumberSeats", c.Request.URL.Query(), &params.MinNumberSeats)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minNumberSeats: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minPrice" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minPrice", c.Request.URL.Query(), &params.MinPrice)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minPrice: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxPrice" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxPrice", c.Request.URL.Query(), &params.MaxPrice)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxPrice: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "carType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "carType", c.Request.URL.Query(), &params.CarType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter carType: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "onlyVollkasko" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "onlyVollkasko", c.Request.URL.Query(), &params.OnlyVollkasko)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter onlyVollkasko: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minFreeKilometer" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minFreeKilometer", c.Request.URL.Query(), &params.MinFreeKilometer)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minFreeKilometer: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOffers(c, params)
+}
+
+// CreateOffers operation middleware
+func (siw *ServerInterfaceWrapper) CreateOffers(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateOffers(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.DELETE(options.BaseURL+"/api/offers", wrapper.CleanupData)
+	router.GET(options.BaseURL+"/api/offers", wrapper.GetOffers)
+	router.POST(options.BaseURL+"/api/offers", wrapper.CreateOffers)
+}
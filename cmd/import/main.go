@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Uploads a CSV or Parquet offer dump to the import endpoint of a running server
+func main() {
+	server := flag.String("server", "http://localhost:80", "Base URL of the server")
+	format := flag.String("format", "", "File format (csv or parquet), detected from the file extension if empty")
+	mappingFile := flag.String("mapping", "", "JSON file mapping offer attributes to column names, e.g. {\"price\": \"price_cents\"}")
+	flag.Usage = func() {
+		fmt.Println("Usage: import [flags] <file>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	query := url.Values{}
+	query.Set("format", *format)
+	if *mappingFile != "" {
+		b, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			fmt.Printf("Error reading mapping: %v\n", err)
+			os.Exit(1)
+		}
+		var mapping map[string]string
+		if err := json.Unmarshal(b, &mapping); err != nil {
+			fmt.Printf("Error parsing mapping: %v\n", err)
+			os.Exit(1)
+		}
+		for attribute, column := range mapping {
+			query.Set("column."+attribute, column)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Error opening file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	resp, err := http.Post(strings.TrimSuffix(*server, "/")+"/api/offers/import?"+query.Encode(), "application/octet-stream", file)
+	if err != nil {
+		fmt.Printf("Error uploading file: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Println(string(body))
+	if resp.StatusCode != http.StatusOK {
+		os.Exit(1)
+	}
+}
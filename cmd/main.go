@@ -1,90 +1,13 @@
 package main
 
 import (
+	"check_republic/recorder"
 	"encoding/json"
 	"fmt"
 	"github.com/atotto/clipboard"
 	"os"
 )
 
-// Define the structures for parsing the log entries
-type LogEntry struct {
-	RequestType string `json:"requestType"`
-	Timestamp   string `json:"timestamp"`
-	Log         Log    `json:"log"`
-}
-
-type Log struct {
-	ID             string        `json:"id"`
-	StartTime      string        `json:"start_time"`
-	Duration       float64       `json:"duration"`
-	WriteConfig    *WriteConfig  `json:"write_config,omitempty"`
-	ExpectedResult *Result       `json:"expected_result,omitempty"`
-	ActualResult   *Result       `json:"actual_result,omitempty"`
-	SearchConfig   *SearchConfig `json:"search_config,omitempty"`
-}
-
-type WriteConfig struct {
-	ID     string  `json:"ID"`
-	Offers []Offer `json:"Offers"`
-}
-
-type Offer struct {
-	OfferID        string `json:"OfferID"`
-	RegionID       int    `json:"RegionID"`
-	CarType        string `json:"CarType"`
-	NumberDays     int    `json:"NumberDays"`
-	NumberSeats    int    `json:"NumberSeats"`
-	StartTimestamp string `json:"StartTimestamp"`
-	EndTimestamp   string `json:"EndTimestamp"`
-	Price          int    `json:"Price"`
-	HasVollkasko   bool   `json:"HasVollkasko"`
-	FreeKilometers int    `json:"FreeKilometers"`
-}
-
-type Result struct {
-	Offers              []OfferResult  `json:"Offers"`
-	CarTypeCounts       map[string]int `json:"CarTypeCounts"`
-	FreeKilometerRanges []RangeCount   `json:"FreeKilometerRanges"`
-	PriceRanges         []RangeCount   `json:"PriceRanges"`
-	SeatsCounts         map[string]int `json:"SeatsCounts"`
-	VollkaskoCount      map[string]int `json:"VollkaskoCount"`
-}
-
-type OfferResult struct {
-	OfferID       string `json:"OfferID"`
-	IsDataCorrect bool   `json:"IsDataCorrect"`
-}
-
-type RangeCount struct {
-	Start int `json:"Start"`
-	End   int `json:"End"`
-	Count int `json:"Count"`
-}
-
-type SearchConfig struct {
-	ID                string     `json:"ID"`
-	RegionID          int        `json:"RegionID"`
-	StartRange        string     `json:"StartRange"`
-	EndRange          string     `json:"EndRange"`
-	NumberDays        int        `json:"NumberDays"`
-	CarType           *string    `json:"CarType,omitempty"`
-	OnlyVollkasko     *bool      `json:"OnlyVollkasko,omitempty"`
-	MinFreeKilometer  *int       `json:"MinFreeKilometer,omitempty"`
-	MinNumberSeats    *int       `json:"MinNumberSeats,omitempty"`
-	MinPrice          *int       `json:"MinPrice,omitempty"`
-	MaxPrice          *int       `json:"MaxPrice,omitempty"`
-	Pagination        Pagination `json:"Pagination"`
-	Order             string     `json:"Order"`
-	PriceBucketWidth  int        `json:"PriceBucketWidth"`
-	FreeKmBucketWidth int        `json:"FreeKmBucketWidth"`
-}
-
-type Pagination struct {
-	Page     int `json:"Page"`
-	PageSize int `json:"PageSize"`
-}
-
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: main <logfile>")
@@ -100,7 +23,7 @@ func main() {
 
 	decoder := json.NewDecoder(file)
 	for decoder.More() {
-		var entry LogEntry
+		var entry recorder.LogEntry
 		err := decoder.Decode(&entry)
 		if err != nil {
 			fmt.Printf("Error decoding log entry: %v\n", err)
@@ -141,7 +64,7 @@ func main() {
 }
 
 // compareResults compares the expected and actual results and returns true if they are equal, false otherwise
-func compareResults(expected, actual *Result) bool {
+func compareResults(expected, actual *recorder.Result) bool {
 	if len(expected.Offers) != len(actual.Offers) {
 		return false
 	}
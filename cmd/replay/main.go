@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"check_republic/models"
+	"check_republic/recorder"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Replays a log recorded with RECORD_FILE against a running server. The entries are written to stdout
+// with the responses of the server as actual_result, so they can be compared with cmd.
+// Offers are only correct if their data matches the posted data.
+func main() {
+	server := flag.String("server", "http://localhost:80", "Base URL of the server")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: replay [flags] <logfile>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	r := &replayer{server: strings.TrimSuffix(*server, "/"), data: map[string]string{}}
+	decoder := json.NewDecoder(file)
+	encoder := json.NewEncoder(os.Stdout)
+	failed := 0
+	for decoder.More() {
+		var entry recorder.LogEntry
+		if err := decoder.Decode(&entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Error decoding log entry: %v\n", err)
+			os.Exit(1)
+		}
+		if err := r.replay(&entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Error replaying %s %s: %v\n", entry.RequestType, entry.Log.ID, err)
+			failed++
+		}
+		encoder.Encode(entry)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+type replayer struct {
+	server string
+	// data of the posted offers by ID
+	data map[string]string
+}
+
+func (r *replayer) replay(entry *recorder.LogEntry) error {
+	switch entry.RequestType {
+	case http.MethodPost:
+		offers, err := entry.Log.WriteConfig.ToOffers()
+		if err != nil {
+			return err
+		}
+		body, _ := json.Marshal(offers)
+		if _, err := r.do(http.MethodPost, "/api/offers", body); err != nil {
+			return err
+		}
+		for _, o := range entry.Log.WriteConfig.Offers {
+			r.data[o.OfferID] = o.Data
+		}
+	case http.MethodDelete:
+		if _, err := r.do(http.MethodDelete, "/api/offers", nil); err != nil {
+			return err
+		}
+		clear(r.data)
+	case http.MethodGet:
+		query, err := entry.Log.SearchConfig.Query()
+		if err != nil {
+			return err
+		}
+		body, err := r.do(http.MethodGet, "/api/offers?"+query.Encode(), nil)
+		if err != nil {
+			// Searches that failed when they were recorded have no expected result
+			if entry.Log.ExpectedResult == nil {
+				return nil
+			}
+			return err
+		}
+		var dto models.DTO
+		if err := json.Unmarshal(body, &dto); err != nil {
+			return err
+		}
+		entry.Log.ActualResult = recorder.NewResult(&dto, func(o *models.OfferDTO) bool {
+			return o.Data != nil && *o.Data == r.data[o.ID]
+		})
+	default:
+		return fmt.Errorf("unknown request type")
+	}
+	return nil
+}
+
+func (r *replayer) do(method string, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, r.server+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"check_republic/metrics"
+	"check_republic/models"
+	"sync"
+)
+
+// ResultCache caches search results by the query. Every write to the database invalidates it,
+// when it is full all entries are dropped.
+type ResultCache struct {
+	mu         sync.Mutex
+	capacity   int
+	generation uint64
+	entries    map[string]models.DTO
+}
+
+func NewResultCache(capacity int) *ResultCache {
+	return &ResultCache{capacity: capacity, entries: make(map[string]models.DTO, capacity)}
+}
+
+// Generation identifies the state of the database, read it before computing a result to put.
+func (c *ResultCache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Get returns the cached result of a query and counts the hit or miss.
+func (c *ResultCache) Get(key string) (models.DTO, bool) {
+	c.mu.Lock()
+	dto, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok {
+		metrics.CacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		metrics.CacheRequests.WithLabelValues("miss").Inc()
+	}
+	return dto, ok
+}
+
+// Put caches a result computed at the given generation, results of invalidated generations are dropped.
+func (c *ResultCache) Put(generation uint64, key string, dto models.DTO) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if len(c.entries) >= c.capacity {
+		clear(c.entries)
+	}
+	c.entries[key] = dto
+}
+
+// Invalidate drops all entries after a write
+func (c *ResultCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
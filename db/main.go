@@ -3,13 +3,18 @@ package db
 import (
 	"check_republic/models"
 	"context"
+	"iter"
+
+	"github.com/google/uuid"
 )
 
 var DB MemoryDB
 
 type OfferDatabase interface {
 	CreateOffers(ctx context.Context, o ...*models.Offer) error
-	GetAllOffers(ctx context.Context) models.Offers
+	GetAllOffers(ctx context.Context) iter.Seq[*models.Offer]
+	GetOffers(ctx context.Context, ids ...uuid.UUID) []*models.Offer
 	DeleteAllOffers(ctx context.Context) error
-	GetFilteredOffers(ctx context.Context, regionID uint64, timeRangeStart uint64, timeRangeEnd uint64, numberDays uint64, sortOrder string, page uint64, pageSize uint64, priceRangeWidth uint64, minFreeKilometerWidth uint64, minNumberSeats *uint64, minPrice *uint64, maxPrice *uint64, carType *string, onlyVollkasko *bool, minFreeKilometer *uint64) models.DTO
+	GetFilteredOffers(ctx context.Context, regionID uint64, timeRangeStart uint64, timeRangeEnd uint64, numberDays uint64, sortOrder string, page uint64, pageSize uint64, priceRanges models.HistogramOptions, freeKilometerRanges models.HistogramOptions, pricePerDayRanges models.HistogramOptions, startDateRanges models.DateHistogramOptions, minNumberSeats *uint64, numberSeats []uint64, minPrice *uint64, maxPrice *uint64, carTypes []string, onlyVollkasko *bool, minFreeKilometer *uint64, minPricePerDay *uint64, maxPricePerDay *uint64, subRegionCounts bool, stats bool, facets []string, fields []string) (models.DTO, error)
+	Search(ctx context.Context, q *models.SearchQuery) (models.DTO, error)
 }
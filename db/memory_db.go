@@ -1,66 +1,160 @@
 package db
 
 import (
+	"check_republic/metrics"
 	"check_republic/models"
+	"check_republic/tracing"
 	"context"
+	"errors"
+	"fmt"
+	"iter"
 	"log/slog"
+	"math/bits"
 	"sort"
+	"strconv"
 	"sync"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrDuplicateOffer is returned for offers with the ID of an existing offer
+var ErrDuplicateOffer = errors.New("duplicate offer ID")
+
+// ErrPageOutOfRange is returned for searches whose page starts beyond the largest index
+var ErrPageOutOfRange = errors.New("page * pageSize is out of range")
+
 type MemoryDB struct {
 	// takes a inner node region and returns all leaf offers in leaf regions
 	regionIdToOffers map[int32][]*models.Offer
+	idToOffer        map[uuid.UUID]*models.Offer
 	rwlock           *sync.RWMutex
+	// Results caches the results of GET searches
+	Results *ResultCache
 }
 
 func InitMemoryDB() {
 	DB = MemoryDB{
 		regionIdToOffers: make(map[int32][]*models.Offer),
+		idToOffer:        make(map[uuid.UUID]*models.Offer),
 		rwlock:           &sync.RWMutex{},
+		Results:          NewResultCache(1024),
 	}
 	slog.Info("Database created")
 }
 
 func (m *MemoryDB) CreateOffers(ctx context.Context, offers ...*models.Offer) error {
+	_, span := tracing.Tracer.Start(ctx, "MemoryDB.CreateOffers", trace.WithAttributes(attribute.Int("offers", len(offers))))
+	defer span.End()
+
 	m.rwlock.Lock()
 	defer m.rwlock.Unlock()
 
+	// Nothing is inserted if an ID already exists or repeats
+	ids := make(map[uuid.UUID]struct{}, len(offers))
+	for _, offer := range offers {
+		_, exists := m.idToOffer[offer.ID]
+		_, repeated := ids[offer.ID]
+		if exists || repeated {
+			return fmt.Errorf("%w: %s", ErrDuplicateOffer, offer.ID)
+		}
+		ids[offer.ID] = struct{}{}
+	}
+
+	touched := make(map[int32]struct{})
 	for _, offer := range offers {
-		offer.NumberDays = (offer.EndDate - offer.StartDate) / models.MsFactor
+		offer.Derive()
+		m.idToOffer[offer.ID] = offer
 		for _, anchecstor := range models.SpecificRegionToAnchestor[int32(offer.MostSpecificRegionID)] {
 			m.regionIdToOffers[anchecstor] = append(m.regionIdToOffers[anchecstor], offer)
+			touched[anchecstor] = struct{}{}
 		}
 	}
 
+	m.Results.Invalidate()
+	metrics.OffersIngested.Add(float64(len(offers)))
+	for regionID := range touched {
+		metrics.OffersPerRegion.WithLabelValues(strconv.Itoa(int(regionID))).Set(float64(len(m.regionIdToOffers[regionID])))
+	}
+
 	return nil
 }
 
-func (m *MemoryDB) GetFilteredOffers(ctx context.Context, regionID uint64, timeRangeStart uint64, timeRangeEnd uint64, numberDays uint64, sortOrder string, page uint64, pageSize uint64, priceRangeWidth uint32, minFreeKilometerWidth uint32, minNumberSeats *uint64, minPrice *uint64, maxPrice *uint64, carType *string, onlyVollkasko *bool, minFreeKilometer *uint64) models.DTO {
+func (m *MemoryDB) GetFilteredOffers(ctx context.Context, regionID uint64, timeRangeStart uint64, timeRangeEnd uint64, numberDays uint64, sortOrder string, page uint64, pageSize uint64, priceRanges models.HistogramOptions, freeKilometerRanges models.HistogramOptions, pricePerDayRanges models.HistogramOptions, startDateRanges models.DateHistogramOptions, minNumberSeats *uint64, numberSeats []uint64, minPrice *uint64, maxPrice *uint64, carTypes []string, onlyVollkasko *bool, minFreeKilometer *uint64, minPricePerDay *uint64, maxPricePerDay *uint64, subRegionCounts bool, stats bool, facets []string, fields []string) (models.DTO, error) {
+	q := &models.SearchQuery{
+		RegionIDs:      []int32{int32(regionID)},
+		TimeRangeStart: timeRangeStart,
+		TimeRangeEnd:   timeRangeEnd,
+		NumberDays:     numberDays,
+		Filters:        models.NewFilters(minNumberSeats, numberSeats, minPrice, maxPrice, carTypes, onlyVollkasko, minFreeKilometer, minPricePerDay, maxPricePerDay),
+		Aggregations: models.AggregationOptions{
+			PriceRanges:         &priceRanges,
+			FreeKilometerRanges: &freeKilometerRanges,
+			PricePerDayRanges:   pricePerDayRanges,
+			StartDateRanges:     startDateRanges,
+			CarTypeCounts:       true,
+			VollkaskoCount:      true,
+			SeatsCount:          true,
+			Stats:               stats,
+		},
+		Sort:     models.ParseSortOrder(sortOrder),
+		Page:     page,
+		PageSize: pageSize,
+		Fields:   models.NewOfferFields(fields),
+	}
+	if subRegionCounts {
+		q.Aggregations.BreakdownRegion = &q.RegionIDs[0]
+	}
+	if facets != nil {
+		q.Aggregations = q.Aggregations.Select(facets)
+	}
+
+	return m.Search(ctx, q)
+}
+
+// Search runs a compiled query. Errors are caused by the query.
+func (m *MemoryDB) Search(ctx context.Context, q *models.SearchQuery) (models.DTO, error) {
+	ctx, span := tracing.Tracer.Start(ctx, "MemoryDB.Search")
+	defer span.End()
+
+	// Calculate the starting and ending indices for pagination
+	overflow, startIndex := bits.Mul64(q.Page, q.PageSize)
+	endIndex, carry := bits.Add64(startIndex, q.PageSize, 0)
+	if overflow != 0 || carry != 0 {
+		return models.DTO{}, ErrPageOutOfRange
+	}
+
+	_, phase := tracing.Tracer.Start(ctx, "offersInRegions")
 	// m.rwlock.RLock()
-	ofs := &models.Offers{Offers: m.regionIdToOffers[int32(regionID)]}
+	ofs := &models.Offers{Offers: m.offersInRegions(q.RegionIDs)}
 	// m.rwlock.RUnlock()
-	required_ofs := ofs.FilterMandatory(timeRangeStart, timeRangeEnd, numberDays)
+	endPhase(phase, len(ofs.Offers))
 
+	_, phase = tracing.Tracer.Start(ctx, "FilterMandatory")
+	required_ofs := ofs.FilterMandatory(q.TimeRangeStart, q.TimeRangeEnd, q.NumberDays)
+	endPhase(phase, len(required_ofs.Offers))
 
 	// Optional filters
-	aggs := required_ofs.FilterAggregations( minNumberSeats, minPrice, maxPrice, carType, onlyVollkasko, minFreeKilometer)
+	_, phase = tracing.Tracer.Start(ctx, "FilterAggregations")
+	aggs, err := required_ofs.FilterAggregations(q.Filters, q.Aggregations)
+	if err != nil {
+		phase.End()
+		return models.DTO{}, err
+	}
+	endPhase(phase, len(aggs.OptionalAgg.Offers))
 
 	optional_ofs := aggs.OptionalAgg
 
-	pricesRange := models.BucketizeOffersByPrice(aggs.PricesAgg.Offers, priceRangeWidth)
-	freeKilometerRange := models.BucketizeOffersByKilometer(aggs.FreeKmAgg.Offers, minFreeKilometerWidth)
-
 	// Sorting
-	if sortOrder == "price-asc" {
-		sort.Sort(models.ByPrice{Offers: optional_ofs.Offers, Asc: true})
-	} else if sortOrder == "price-desc" {
-		sort.Sort(models.ByPrice{Offers: optional_ofs.Offers, Asc: false})
-	}
+	_, phase = tracing.Tracer.Start(ctx, "SortOffers")
+	models.SortOffers(optional_ofs.Offers, q.Sort)
+	endPhase(phase, len(optional_ofs.Offers))
 
-	// Calculate the starting and ending indices for pagination
-	startIndex := page * pageSize
-	endIndex := startIndex + pageSize
+	_, phase = tracing.Tracer.Start(ctx, "buildDTO")
+	defer phase.End()
+
+	page, pageSize := q.Page, q.PageSize
 
 	// Ensure indices are within bounds
 	if startIndex > uint64(len(optional_ofs.Offers)) {
@@ -75,51 +169,111 @@ func (m *MemoryDB) GetFilteredOffers(ctx context.Context, regionID uint64, timeR
 
 	var dto_offers = make([]*models.OfferDTO, 0, len(paginatedOffers))
 	for _, offer := range paginatedOffers {
-		dto_offers = append(dto_offers, &models.OfferDTO{
-			ID:   offer.ID.String(),
-			Data: offer.Data,
-		})
+		dto_offers = append(dto_offers, models.NewOfferDTO(offer, q.Fields))
 	}
 
-	seatsCountSlice := []*models.KVSeatsCount{}
-	// Transform the data correctly
-	for _, v := range aggs.SeatsCount {
-		seatsCountSlice = append(seatsCountSlice, v)
-	}
-	sort.Slice(seatsCountSlice, func(i, j int) bool {
-		return seatsCountSlice[i].NumberSeats < seatsCountSlice[j].NumberSeats
-	})
-
-	transformedPricesRange := make([]models.HistogramRange, 0, len(pricesRange))
-	for _, offer := range pricesRange {
-		transformedPricesRange = append(transformedPricesRange, models.HistogramRange{
-			Start: offer.Start,
-			End:   offer.End,
-			Count: offer.Count,
+	var seatsCount *[]*models.KVSeatsCount
+	if aggs.SeatsCount != nil {
+		seatsCountSlice := []*models.KVSeatsCount{}
+		// Transform the data correctly
+		for _, v := range aggs.SeatsCount {
+			seatsCountSlice = append(seatsCountSlice, v)
+		}
+		sort.Slice(seatsCountSlice, func(i, j int) bool {
+			return seatsCountSlice[i].NumberSeats < seatsCountSlice[j].NumberSeats
 		})
+		seatsCount = &seatsCountSlice
 	}
 
-	transformedKmRange := make([]models.HistogramRange, 0, len(freeKilometerRange))
-	for _, offer := range freeKilometerRange {
-		transformedKmRange = append(transformedKmRange, models.HistogramRange{
-			Start: offer.Start,
-			End:   offer.End,
-			Count: offer.Count,
-		})
-	}
+	metrics.SearchMatches.Observe(float64(len(optional_ofs.Offers)))
+	metrics.SearchResults.Observe(float64(len(paginatedOffers)))
 
-	return models.DTO{
+	dto := models.DTO{
 		Offers:             dto_offers,
 		CarTypeCounts:      aggs.CarTypeCount,
 		VollkaskoCount:     aggs.VollKaskoCount,
-		SeatsCount:         seatsCountSlice,
-		PriceRanges:        transformedPricesRange,
-		FreeKilometerRange: transformedKmRange,
+		SeatsCount:         seatsCount,
+		PriceRanges:        aggs.PriceRanges,
+		FreeKilometerRange: aggs.FreeKilometerRange,
+		PricePerDayRanges:  aggs.PricePerDayRanges,
+		StartDateRanges:    aggs.StartDateRanges,
+		Stats:              aggs.Stats,
+		Meta: models.PageMeta{
+			Total:    uint64(len(optional_ofs.Offers)),
+			Page:     page,
+			PageSize: pageSize,
+			HasNext:  endIndex < uint64(len(optional_ofs.Offers)),
+		},
+	}
+	if aggs.SubRegionCount != nil {
+		dto.SubRegionCounts = aggs.SubRegionCount.Slice()
+	}
+
+	return dto, nil
+}
+
+// endPhase records the number of offers a phase of a search resulted in
+func endPhase(span trace.Span, offers int) {
+	span.SetAttributes(attribute.Int("offers", offers))
+	span.End()
+}
+
+// offersInRegions returns the offers of all regions, offers of overlapping regions are only included once
+func (m *MemoryDB) offersInRegions(regionIDs []int32) []*models.Offer {
+	if len(regionIDs) == 1 {
+		return m.regionIdToOffers[regionIDs[0]]
+	}
+
+	seen := make(map[*models.Offer]struct{})
+	offers := make([]*models.Offer, 0)
+	for _, regionID := range regionIDs {
+		for _, offer := range m.regionIdToOffers[regionID] {
+			if _, ok := seen[offer]; !ok {
+				seen[offer] = struct{}{}
+				offers = append(offers, offer)
+			}
+		}
 	}
+	return offers
+}
+
+// GetAllOffers iterates over all offers in insertion order without copying them.
+// Offers created during the iteration are not included.
+func (m *MemoryDB) GetAllOffers(ctx context.Context) iter.Seq[*models.Offer] {
+	m.rwlock.RLock()
+	// Every offer is stored once in the slice of the root region, which is only ever appended to
+	offers := m.regionIdToOffers[models.RootRegionID]
+	m.rwlock.RUnlock()
+
+	return func(yield func(*models.Offer) bool) {
+		for _, offer := range offers {
+			if ctx.Err() != nil || !yield(offer) {
+				return
+			}
+		}
+	}
+}
+
+// GetOffers returns the offers with the given IDs in the same order, nil for unknown IDs
+func (m *MemoryDB) GetOffers(ctx context.Context, ids ...uuid.UUID) []*models.Offer {
+	m.rwlock.RLock()
+	defer m.rwlock.RUnlock()
+
+	offers := make([]*models.Offer, len(ids))
+	for i, id := range ids {
+		offers[i] = m.idToOffer[id]
+	}
+	return offers
 }
 
 func (m *MemoryDB) DeleteAllOffers(ctx context.Context) error {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+
 	m.regionIdToOffers = make(map[int32][]*models.Offer)
+	m.idToOffer = make(map[uuid.UUID]*models.Offer)
+	m.Results.Invalidate()
+	metrics.OffersPerRegion.Reset()
 
 	return nil
 }
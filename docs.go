@@ -0,0 +1,78 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed spec.yml
+var spec []byte
+
+// explorerAssets is swagger-ui-dist 5.29.0, so the explorer works without network access
+//
+//go:embed docs/swagger-ui
+var explorerAssets embed.FS
+
+// explorerPage renders the served spec with Swagger UI
+const explorerPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>checkrepublic API</title>
+  <link rel="stylesheet" href="/docs/assets/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/docs/assets/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.yaml", dom_id: "#swagger-ui", tryItOutEnabled: true});
+  </script>
+</body>
+</html>
+`
+
+func specHandler(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", spec)
+}
+
+func docsHandler(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(explorerPage))
+}
+
+// docsAssetHandler serves the embedded Swagger UI files
+func docsAssetHandler(c *gin.Context) {
+	c.FileFromFS("docs/swagger-ui/"+c.Param("file"), http.FS(explorerAssets))
+}
+
+var ginParam = regexp.MustCompile(`[:*](\w+)`)
+
+// checkSpecRoutes logs the routes that are missing in the spec and the operations of the spec that have no route
+func checkSpecRoutes(routes gin.RoutesInfo) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		slog.Error("Error loading spec", "error", err)
+		return
+	}
+
+	routed := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+		routed[route.Method+" "+path] = true
+		if item := doc.Paths.Find(path); item == nil || item.GetOperation(route.Method) == nil {
+			slog.Warn("Route is not documented in spec.yml", "method", route.Method, "path", path)
+		}
+	}
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !routed[fmt.Sprintf("%s %s", method, path)] {
+				slog.Warn("Operation of spec.yml has no route", "method", method, "path", path)
+			}
+		}
+	}
+}
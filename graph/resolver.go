@@ -0,0 +1,331 @@
+package graph
+
+import (
+	"check_republic/db"
+	"check_republic/models"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Resolver resolves the Query type of the schema
+type Resolver struct {
+	DB db.OfferDatabase
+}
+
+func (r *Resolver) Region(args struct{ ID *int32 }) *region {
+	id := int32(models.RootRegionID)
+	if args.ID != nil {
+		id = *args.ID
+	}
+	return newRegion(id)
+}
+
+func (r *Resolver) Offer(ctx context.Context, args struct{ ID graphql.ID }) *offer {
+	return r.Offers(ctx, struct{ IDs []graphql.ID }{IDs: []graphql.ID{args.ID}})[0]
+}
+
+func (r *Resolver) Offers(ctx context.Context, args struct{ IDs []graphql.ID }) []*offer {
+	ret := make([]*offer, len(args.IDs))
+
+	// Unknown and malformed IDs are both not found
+	ids := make([]uuid.UUID, 0, len(args.IDs))
+	positions := make([]int, 0, len(args.IDs))
+	for i, id := range args.IDs {
+		parsed, err := uuid.Parse(string(id))
+		if err != nil {
+			continue
+		}
+		ids = append(ids, parsed)
+		positions = append(positions, i)
+	}
+
+	for i, o := range r.DB.GetOffers(ctx, ids...) {
+		if o != nil {
+			ret[positions[i]] = newOffer(models.NewOfferDTO(o, models.AllOfferFields))
+		}
+	}
+	return ret
+}
+
+// SearchInput mirrors the query parameters of GET /api/offers
+type SearchInput struct {
+	RegionID              int32
+	TimeRangeStart        Long
+	TimeRangeEnd          Long
+	NumberDays            Long
+	SortOrder             string
+	Page                  Long
+	PageSize              Long
+	PriceRangeWidth       *Long
+	MinFreeKilometerWidth *Long
+	PricePerDayRangeWidth *Long
+	StartDateInterval     *string
+	TimeZone              *string
+	MinNumberSeats        *Long
+	NumberSeats           *[]Long
+	MinPrice              *Long
+	MaxPrice              *Long
+	CarType               *[]string
+	OnlyVollkasko         *bool
+	MinFreeKilometer      *Long
+	MinPricePerDay        *Long
+	MaxPricePerDay        *Long
+}
+
+func (r *Resolver) Search(ctx context.Context, args struct{ Query SearchInput }) (*searchResult, error) {
+	q := args.Query
+
+	startDateRanges := models.DateHistogramOptions{}
+	if q.StartDateInterval != nil {
+		startDateRanges.Interval = *q.StartDateInterval
+	}
+	if q.TimeZone != nil {
+		location, err := time.LoadLocation(*q.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		startDateRanges.Location = location
+	}
+
+	var numberSeats []uint64
+	if q.NumberSeats != nil {
+		for _, n := range *q.NumberSeats {
+			numberSeats = append(numberSeats, uint64(n))
+		}
+	}
+	var carTypes []string
+	if q.CarType != nil {
+		carTypes = *q.CarType
+	}
+
+	// Only the selected aggregations are computed
+	facets := graphql.SelectedFieldNames(ctx)
+
+	dto, err := r.DB.GetFilteredOffers(ctx,
+		uint64(q.RegionID),
+		uint64(q.TimeRangeStart),
+		uint64(q.TimeRangeEnd),
+		uint64(q.NumberDays),
+		q.SortOrder,
+		uint64(q.Page),
+		uint64(q.PageSize),
+		histogramOptions(q.PriceRangeWidth),
+		histogramOptions(q.MinFreeKilometerWidth),
+		histogramOptions(q.PricePerDayRangeWidth),
+		startDateRanges,
+		uint64Ptr(q.MinNumberSeats),
+		numberSeats,
+		uint64Ptr(q.MinPrice),
+		uint64Ptr(q.MaxPrice),
+		carTypes,
+		q.OnlyVollkasko,
+		uint64Ptr(q.MinFreeKilometer),
+		uint64Ptr(q.MinPricePerDay),
+		uint64Ptr(q.MaxPricePerDay),
+		graphql.HasSelectedField(ctx, "subRegionCounts"),
+		graphql.HasSelectedField(ctx, "stats"),
+		facets,
+		models.AllOfferFieldNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSearchResult(&dto), nil
+}
+
+func histogramOptions(width *Long) models.HistogramOptions {
+	if width == nil {
+		return models.HistogramOptions{}
+	}
+	return models.HistogramOptions{Width: uint64(*width)}
+}
+
+func uint64Ptr(l *Long) *uint64 {
+	if l == nil {
+		return nil
+	}
+	v := uint64(*l)
+	return &v
+}
+
+type region struct {
+	ID         int32
+	Name       string
+	SubRegions []*region
+}
+
+func newRegion(id int32) *region {
+	r, ok := models.RegionsByID[id]
+	if !ok {
+		return nil
+	}
+	return convertRegion(r)
+}
+
+func convertRegion(r *models.Region) *region {
+	ret := &region{ID: r.Id, Name: r.Name, SubRegions: make([]*region, 0, len(r.SubRegions))}
+	for i := range r.SubRegions {
+		ret.SubRegions = append(ret.SubRegions, convertRegion(&r.SubRegions[i]))
+	}
+	return ret
+}
+
+type offer struct {
+	ID                   graphql.ID
+	Data                 string
+	MostSpecificRegionID int32
+	StartDate            Long
+	EndDate              Long
+	NumberDays           Long
+	NumberSeats          Long
+	Price                Long
+	PricePerDay          Long
+	CarType              string
+	HasVollkasko         bool
+	FreeKilometers       Long
+}
+
+// newOffer converts an offer DTO with all fields selected
+func newOffer(o *models.OfferDTO) *offer {
+	return &offer{
+		ID:                   graphql.ID(o.ID),
+		Data:                 *o.Data,
+		MostSpecificRegionID: int32(*o.MostSpecificRegionID),
+		StartDate:            Long(*o.StartDate),
+		EndDate:              Long(*o.EndDate),
+		NumberDays:           Long(*o.NumberDays),
+		NumberSeats:          Long(*o.NumberSeats),
+		Price:                Long(*o.Price),
+		PricePerDay:          Long(*o.PricePerDay),
+		CarType:              *o.CarType,
+		HasVollkasko:         *o.HasVollkasko,
+		FreeKilometers:       Long(*o.FreeKilometers),
+	}
+}
+
+func (o *offer) Region() *region {
+	return newRegion(o.MostSpecificRegionID)
+}
+
+type histogramRange struct {
+	Start Long
+	End   Long
+	Count Long
+}
+
+type carTypeCount struct {
+	CarType string
+	Count   Long
+}
+
+type seatsCount struct {
+	NumberSeats Long
+	Count       Long
+}
+
+type vollkaskoCount struct {
+	TrueCount  Long
+	FalseCount Long
+}
+
+type subRegionCount struct {
+	RegionID int32
+	Name     string
+	Count    Long
+}
+
+type stats struct {
+	Count  Long
+	Min    Long
+	Max    Long
+	Mean   float64
+	Median Long
+	P90    Long
+}
+
+type statsSummary struct {
+	Price          stats
+	FreeKilometers stats
+}
+
+type pageMeta struct {
+	Total    Long
+	Page     Long
+	PageSize Long
+	HasNext  bool
+}
+
+type searchResult struct {
+	Offers             []*offer
+	PriceRanges        []histogramRange
+	CarTypeCounts      []carTypeCount
+	SeatsCount         []seatsCount
+	FreeKilometerRange []histogramRange
+	VollkaskoCount     vollkaskoCount
+	PricePerDayRanges  []histogramRange
+	StartDateRanges    []histogramRange
+	SubRegionCounts    []subRegionCount
+	Stats              *statsSummary
+	Meta               pageMeta
+}
+
+func newSearchResult(dto *models.DTO) *searchResult {
+	ret := &searchResult{
+		Offers:             make([]*offer, 0, len(dto.Offers)),
+		PriceRanges:        convertRanges(valueOf(dto.PriceRanges)),
+		CarTypeCounts:      make([]carTypeCount, 0, len(dto.CarTypeCounts)),
+		SeatsCount:         make([]seatsCount, 0),
+		FreeKilometerRange: convertRanges(valueOf(dto.FreeKilometerRange)),
+		PricePerDayRanges:  convertRanges(valueOf(dto.PricePerDayRanges)),
+		StartDateRanges:    convertRanges(valueOf(dto.StartDateRanges)),
+		SubRegionCounts:    make([]subRegionCount, 0, len(dto.SubRegionCounts)),
+		Meta:               pageMeta{Total: Long(dto.Meta.Total), Page: Long(dto.Meta.Page), PageSize: Long(dto.Meta.PageSize), HasNext: dto.Meta.HasNext},
+	}
+	for _, o := range dto.Offers {
+		ret.Offers = append(ret.Offers, newOffer(o))
+	}
+	for carType, count := range dto.CarTypeCounts {
+		ret.CarTypeCounts = append(ret.CarTypeCounts, carTypeCount{CarType: carType, Count: Long(count)})
+	}
+	sort.Slice(ret.CarTypeCounts, func(i, j int) bool {
+		return ret.CarTypeCounts[i].CarType < ret.CarTypeCounts[j].CarType
+	})
+	// Only the selected fields are computed, so the others are never resolved
+	if dto.VollkaskoCount != nil {
+		ret.VollkaskoCount = vollkaskoCount{TrueCount: Long(dto.VollkaskoCount.TrueCount), FalseCount: Long(dto.VollkaskoCount.FalseCount)}
+	}
+	for _, s := range valueOf(dto.SeatsCount) {
+		ret.SeatsCount = append(ret.SeatsCount, seatsCount{NumberSeats: Long(s.NumberSeats), Count: Long(s.Count)})
+	}
+	for _, s := range dto.SubRegionCounts {
+		ret.SubRegionCounts = append(ret.SubRegionCounts, subRegionCount{RegionID: s.RegionID, Name: s.Name, Count: Long(s.Count)})
+	}
+	if dto.Stats != nil {
+		ret.Stats = &statsSummary{Price: convertStats(dto.Stats.Price), FreeKilometers: convertStats(dto.Stats.FreeKilometers)}
+	}
+	return ret
+}
+
+// valueOf returns the value p points to or the zero value if p is nil
+func valueOf[T any](p *T) (v T) {
+	if p != nil {
+		v = *p
+	}
+	return v
+}
+
+func convertRanges(ranges []models.HistogramRange) []histogramRange {
+	ret := make([]histogramRange, 0, len(ranges))
+	for _, r := range ranges {
+		ret = append(ret, histogramRange{Start: Long(r.Start), End: Long(r.End), Count: Long(r.Count)})
+	}
+	return ret
+}
+
+func convertStats(s models.Stats) stats {
+	return stats{Count: Long(s.Count), Min: Long(s.Min), Max: Long(s.Max), Mean: s.Mean, Median: Long(s.Median), P90: Long(s.P90)}
+}
@@ -0,0 +1,71 @@
+// Package graph serves the offers, regions and aggregations as a GraphQL API.
+package graph
+
+import (
+	"check_republic/db"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// NewHandler parses the schema and returns a handler for POSTed GraphQL queries resolved through the database
+func NewHandler(database db.OfferDatabase) http.Handler {
+	s := graphql.MustParseSchema(schema, &Resolver{DB: database}, graphql.UseFieldResolvers())
+	return &relay.Handler{Schema: s}
+}
+
+// Long is the Long scalar of the schema. GraphQL integers only have 32 bits.
+type Long uint64
+
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		if v < 0 {
+			return fmt.Errorf("Long must not be negative: %d", v)
+		}
+		*l = Long(v)
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("Long must not be negative: %d", v)
+		}
+		*l = Long(v)
+	case float64:
+		// Variables are decoded as float64
+		if v < 0 || v != math.Trunc(v) || v > math.MaxUint64 {
+			return fmt.Errorf("Long must be a non negative integer: %v", v)
+		}
+		*l = Long(v)
+	case string:
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*l = Long(parsed)
+	case json.Number:
+		parsed, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return err
+		}
+		*l = Long(parsed)
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(l), 10), nil
+}
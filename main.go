@@ -1,21 +1,44 @@
 package main
 
 import (
+	"check_republic/accesslog"
+	"check_republic/api"
 	"check_republic/db"
+	"check_republic/graph"
+	"check_republic/metrics"
 	"check_republic/models"
+	"check_republic/recorder"
+	"check_republic/rpc"
+	"check_republic/tracing"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 
 )
 
-var filename = time.Now().String()
+// filename is the access log file in the logs directory if LogToFile is set
+var filename = time.Now().Format("2006-01-02T15-04-05") + ".log"
 var LogToFile = os.Getenv("LOG") == "true"
 
 func main() {
@@ -23,15 +46,90 @@ func main() {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	// The server stops on SIGINT and SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(ctx)
+	if err != nil {
+		log.Panic(err)
+	}
+	// Deferred functions run after a signal and on log.Panic, so the remaining spans are exported when the server stops
+	defer shutdownTracing(context.Background())
+
 	models.InitRegions()
+	models.InitCarTypes()
 
 	// db.InitPostgres()
 	db.InitMemoryDB()
 
+	// Record the requests of /api/offers and /api/v1/offers for replay, see recorder.Middleware
+	var middlewares []gin.HandlerFunc
+	if recordFile := os.Getenv("RECORD_FILE"); recordFile != "" {
+		rec, err := recorder.New(recordFile)
+		if err != nil {
+			log.Panic(err)
+		}
+		defer rec.Close()
+		middlewares = append(middlewares, rec.Middleware())
+	}
+
 	gin.SetMode(gin.ReleaseMode)
+	r := newRouter(accesslog.NewLogger(LogToFile, filename), middlewares...)
+	checkSpecRoutes(r.Routes())
+
+	// gRPC server next to the HTTP API
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Panic(err)
+	}
+	grpcServer := grpc.NewServer()
+	rpc.RegisterOffersServer(grpcServer, &rpc.Server{DB: &db.DB})
+	errs := make(chan error, 2)
+	go func() {
+		errs <- grpcServer.Serve(lis)
+	}()
+
+	srv := &http.Server{Addr: ":80", Handler: r}
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		log.Panic(err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	// Running requests finish before the deferred functions flush the spans and the recording
+	slog.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error shutting down the HTTP server", "error", err)
+	}
+	go func() {
+		<-shutdownCtx.Done()
+		grpcServer.Stop()
+	}()
+	grpcServer.GracefulStop()
+}
+
+// newRouter registers the middlewares and routes of the HTTP API. The extra middlewares run after
+// the gzip middleware, so they see the uncompressed responses.
+func newRouter(accessLog *slog.Logger, extra ...gin.HandlerFunc) *gin.Engine {
 	r := gin.New()
+	r.Use(accesslog.Middleware(accessLog))
 	r.Use(gin.ErrorLogger())
+	// Before the recovery, so requests that panic are observed with status 500
+	r.Use(metrics.Middleware())
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware(tracing.ServiceName))
 
 	// Increase allowed body size
 	r.Use(func(c *gin.Context) {
@@ -40,127 +138,498 @@ func main() {
 	})
 
 	// Gzip compression
-	r.Use(gzip.Gzip(gzip.BestSpeed))
+	// The gzip writer would hold back the progress of bulk ingestions
+	r.Use(gzip.Gzip(gzip.BestSpeed, gzip.WithExcludedPaths([]string{"/api/offers/bulk"})))
+	r.Use(extra...)
 
-	r.GET("/api/offers", getHandler)
-	r.POST("/api/offers", postHandler)
-	r.DELETE("/api/offers", deleteHandler)
-
-	log.Panic(r.Run(":80"))
+	api.RegisterHandlersWithOptions(r, server{}, api.GinServerOptions{ErrorHandler: paramErrorHandler})
+	// The unversioned routes are version 1
+	v1 := api.ServerInterfaceWrapper{Handler: server{}, ErrorHandler: paramErrorHandler}
+	r.GET("/api/v1/offers", v1.GetOffers)
+	v2 := api.ServerInterfaceWrapper{Handler: serverV2{}, ErrorHandler: paramErrorHandler}
+	r.GET("/api/v2/offers", v2.GetOffers)
+	r.POST("/api/offers/search", searchHandler)
+	r.POST("/api/offers/bulk", bulkHandler)
+	r.POST("/api/offers/import", importHandler)
+	r.GET("/api/offers/all", exportHandler)
+	r.GET("/api/offers/:id", getOfferHandler)
+	r.POST("/api/offers/lookup", lookupHandler)
+	r.POST("/graphql", gin.WrapH(graph.NewHandler(&db.DB)))
+	r.GET("/openapi.yaml", specHandler)
+	r.GET("/docs", docsHandler)
+	r.GET("/docs/assets/:file", docsAssetHandler)
+	r.GET("/metrics", metrics.Handler())
+	return r
 }
 
-func postHandler(c *gin.Context) {
+func (server) CreateOffers(c *gin.Context) {
 	var offer models.Offers
 
 	// Parse the request body
-	if err := c.ShouldBindJSON(&offer); err != nil {
+	if err := bindBody(c, &offer); err != nil {
 		slog.Error("Error parsing request body", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if err := offer.Validate(); err != nil {
+		slog.Error("Invalid offer", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	err := db.DB.CreateOffers(c.Request.Context(), offer.Offers...)
 	if err != nil {
 		slog.Error("Error creating offers", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(insertErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	recorder.SetOffers(c, &offer)
 	c.String(http.StatusOK, "Offer created")
 }
 
-func getHandler(c *gin.Context) {
-	regionIDParam := c.Query("regionID")
-	regionID, _ := strconv.ParseUint(regionIDParam, 10, 64)
-
-	timeRangeStartParam := c.Query("timeRangeStart")
-	timeRangeStart, _ := strconv.ParseUint(timeRangeStartParam, 10, 64)
-	timeRangeEndParam := c.Query("timeRangeEnd")
-	timeRangeEnd, _ := strconv.ParseUint(timeRangeEndParam, 10, 64)
-	numberDaysParam := c.Query("numberDays")
-	numberDays, _ := strconv.ParseUint(numberDaysParam, 10, 64)
-	sortOrderParam := c.Query("sortOrder")
-	pageParam := c.Query("page")
-	page, _ := strconv.ParseUint(pageParam, 10, 64)
-	pageSizeParam := c.Query("pageSize")
-	pageSize, _ := strconv.ParseUint(pageSizeParam, 10, 64)
-	priceRangeWidthParam := c.Query("priceRangeWidth")
-	priceRangeWidth, _ := strconv.ParseUint(priceRangeWidthParam, 10, 32)
-	minFreeKilometerWidthParam := c.Query("minFreeKilometerWidth")
-	minFreeKilometerWidth, _ := strconv.ParseUint(minFreeKilometerWidthParam, 10, 32)
-	minNumberSeatsParam := c.Query("minNumberSeats")
-	var minNumberSeats *uint64
-	if minNumberSeatsParam == "" {
-		minNumberSeats = nil
-	} else {
-		parsedValue, _ := strconv.ParseUint(minNumberSeatsParam, 10, 64)
-		minNumberSeats = &parsedValue // Initialize the pointer with a valid address
+// insertErrorStatus answers offers with existing IDs with a conflict
+func insertErrorStatus(err error) int {
+	if errors.Is(err, db.ErrDuplicateOffer) {
+		return http.StatusConflict
 	}
+	return http.StatusInternalServerError
+}
 
-	var minPrice *uint64
-	minPriceParam := c.Query("minPrice")
-	if minPriceParam == "" {
-		minPrice = nil
-	} else {
-		parsed, _ := strconv.ParseUint(minPriceParam, 10, 64)
-		minPrice = &parsed
+// server implements the operations of spec.yml
+type server struct{}
+
+var _ api.ServerInterface = server{}
+
+func (server) GetOffers(c *gin.Context, params api.GetOffersParams) {
+	offers, err := filteredOffers(c, params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	respond(c, http.StatusOK, offers)
+}
 
-	var maxPrice *uint64
-	maxPriceParam := c.Query("maxPrice")
-	if maxPriceParam == "" {
-		maxPrice = nil
-	} else {
-		parsed, _ := strconv.ParseUint(maxPriceParam, 10, 64)
-		maxPrice = &parsed
+// serverV2 answers the search of the spec with the version 2 response
+type serverV2 struct {
+	server
+}
+
+func (serverV2) GetOffers(c *gin.Context, params api.GetOffersParams) {
+	start := time.Now()
+	offers, err := filteredOffers(c, params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	respond(c, http.StatusOK, models.NewDTOV2(&offers, time.Since(start)))
+}
+
+// filteredOffers reads the spec parameters from params and the extensions of the spec from the query.
+// Errors are caused by invalid parameters.
+func filteredOffers(c *gin.Context, params api.GetOffersParams) (models.DTO, error) {
+	// The query determines the result, the versions only differ in the response
+	cacheKey := c.Request.URL.Query().Encode()
+	generation := db.DB.Results.Generation()
+	if offers, ok := db.DB.Results.Get(cacheKey); ok {
+		accesslog.SetResultCount(c, len(offers.Offers))
+		return offers, nil
 	}
 
-	var carType *string
-	carTypeParam := c.Query("carType")
-	if carTypeParam == "" {
-		carType = nil
-	} else {
-		carType = &carTypeParam
+	_, span := tracing.Tracer.Start(c.Request.Context(), "parseParameters")
+	priceRanges := parseHistogramOptions(c, "priceRange")
+	freeKilometerRanges := parseHistogramOptions(c, "freeKilometerRange")
+	// The width is the parameter of the spec
+	freeKilometerRanges.Width = uint64(params.MinFreeKilometerWidth)
+	pricePerDayRanges := parseHistogramOptions(c, "pricePerDayRange")
+	startDateRanges := models.DateHistogramOptions{Interval: c.Query("startDateInterval")}
+	if timeZoneParam := c.Query("timeZone"); timeZoneParam != "" {
+		location, err := time.LoadLocation(timeZoneParam)
+		if err != nil {
+			span.End()
+			return models.DTO{}, fmt.Errorf("unknown time zone %q", timeZoneParam)
+		}
+		startDateRanges.Location = location
+	}
+
+	// Multiple values are given as repeated or comma separated parameters
+	var carTypes []string
+	if params.CarType != nil {
+		carTypes = splitList(*params.CarType)
 	}
 
-	var onlyVollkasko *bool
-	onlyVollkaskoParam := c.Query("onlyVollkasko")
-	if onlyVollkaskoParam == "" {
-		onlyVollkasko = nil
+	var numberSeats []uint64
+	for _, v := range queryList(c, "numberSeats") {
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			continue
+		}
+		numberSeats = append(numberSeats, parsed)
+	}
+
+	var minPricePerDay *uint64
+	minPricePerDayParam := c.Query("minPricePerDay")
+	if minPricePerDayParam == "" {
+		minPricePerDay = nil
 	} else {
-		parsed, _ := strconv.ParseBool(onlyVollkaskoParam)
-		onlyVollkasko = &parsed
+		parsed, _ := strconv.ParseUint(minPricePerDayParam, 10, 64)
+		minPricePerDay = &parsed
 	}
 
-	var minFreeKilometer *uint64
-	minFreeKilometerParam := c.Query("minFreeKilometer")
-	if minFreeKilometerParam == "" {
-		minFreeKilometer = nil
+	var maxPricePerDay *uint64
+	maxPricePerDayParam := c.Query("maxPricePerDay")
+	if maxPricePerDayParam == "" {
+		maxPricePerDay = nil
 	} else {
-		parsed, _ := strconv.ParseUint(minFreeKilometerParam, 10, 64)
-		minFreeKilometer = &parsed
-	}
-
-	offers := db.DB.GetFilteredOffers(c.Request.Context(),
-		regionID,
-		timeRangeStart,
-		timeRangeEnd,
-		numberDays,
-		sortOrderParam,
-		page,
-		pageSize,
-		uint32(priceRangeWidth),
-		uint32(minFreeKilometerWidth),
-		minNumberSeats,
-		minPrice,
-		maxPrice,
-		carType,
-		onlyVollkasko,
-		minFreeKilometer)
-
-	c.JSON(http.StatusOK, offers)
-}
-
-func deleteHandler(c *gin.Context) {
+		parsed, _ := strconv.ParseUint(maxPricePerDayParam, 10, 64)
+		maxPricePerDay = &parsed
+	}
+
+	subRegionCounts, _ := strconv.ParseBool(c.Query("subRegionCounts"))
+	stats, _ := strconv.ParseBool(c.Query("stats"))
+
+	// Only the listed aggregations are computed if facets is given
+	var facets []string
+	if _, ok := c.GetQuery("facets"); ok {
+		facets = append([]string{}, queryList(c, "facets")...)
+		if subRegionCounts {
+			facets = append(facets, "subRegionCounts")
+		}
+		if stats {
+			facets = append(facets, "stats")
+		}
+	}
+	fields := queryList(c, "fields")
+	span.End()
+
+	offers, err := db.DB.GetFilteredOffers(c.Request.Context(),
+		uint64(params.RegionID),
+		uint64(params.TimeRangeStart),
+		uint64(params.TimeRangeEnd),
+		uint64(params.NumberDays),
+		string(params.SortOrder),
+		uint64(params.Page),
+		uint64(params.PageSize),
+		priceRanges,
+		freeKilometerRanges,
+		pricePerDayRanges,
+		startDateRanges,
+		widen(params.MinNumberSeats),
+		numberSeats,
+		widen(params.MinPrice),
+		widen(params.MaxPrice),
+		carTypes,
+		params.OnlyVollkasko,
+		widen(params.MinFreeKilometer),
+		minPricePerDay,
+		maxPricePerDay,
+		subRegionCounts,
+		stats,
+		facets,
+		fields)
+	if err != nil {
+		return models.DTO{}, err
+	}
+	db.DB.Results.Put(generation, cacheKey, offers)
+	accesslog.SetResultCount(c, len(offers.Offers))
+	return offers, nil
+}
+
+// mimeNDJSON is the content type of newline delimited JSON
+const mimeNDJSON = "application/x-ndjson"
+
+// bulkHandler streams newline delimited offers from the body into the database in batches.
+// Clients accepting application/x-ndjson get the report after every batch, the last line is the final report.
+func bulkHandler(c *gin.Context) {
+	ingester := models.NewIngester(10000, func(offers []*models.Offer) error {
+		return db.DB.CreateOffers(c.Request.Context(), offers...)
+	})
+
+	var progress func(report any)
+	if c.NegotiateFormat(binding.MIMEJSON, mimeNDJSON) == mimeNDJSON {
+		c.Header("Content-Type", mimeNDJSON)
+		c.Status(http.StatusOK)
+		encoder := json.NewEncoder(c.Writer)
+		progress = func(report any) {
+			if err := encoder.Encode(report); err != nil {
+				slog.Error("Error writing the ingestion progress", "error", err)
+			}
+			c.Writer.Flush()
+		}
+		ingester.Progress = func(report models.IngestReport) { progress(report) }
+	}
+
+	if err := ingester.IngestNDJSON(c.Request.Body); err != nil {
+		slog.Error("Error ingesting offers", "error", err, "created", ingester.Report.Created)
+		if progress != nil {
+			// The status was already sent
+			progress(gin.H{"error": err.Error(), "report": ingester.Report})
+			return
+		}
+		c.JSON(insertErrorStatus(err), gin.H{"error": err.Error(), "report": ingester.Report})
+		return
+	}
+	if progress != nil {
+		progress(ingester.Report)
+		return
+	}
+	c.JSON(http.StatusOK, ingester.Report)
+}
+
+// importHandler imports an uploaded CSV or Parquet file (format parameter). The columns are
+// mapped to the offer attributes with column.<attribute>=<column name> parameters.
+func importHandler(c *gin.Context) {
+	mapping := models.ColumnMapping{}
+	for key, values := range c.Request.URL.Query() {
+		if attribute, ok := strings.CutPrefix(key, "column."); ok && len(values) > 0 {
+			mapping[attribute] = values[0]
+		}
+	}
+
+	ingester := models.NewIngester(10000, func(offers []*models.Offer) error {
+		return db.DB.CreateOffers(c.Request.Context(), offers...)
+	})
+
+	var err error
+	switch format := c.Query("format"); format {
+	case models.FormatCSV:
+		err = ingester.IngestCSV(c.Request.Body, mapping)
+	case models.FormatParquet:
+		// Parquet files are read from the end, so the upload is buffered in a temporary file
+		var file *os.File
+		var size int64
+		file, size, err = bufferToFile(c.Request.Body)
+		if err == nil {
+			defer os.Remove(file.Name())
+			defer file.Close()
+			err = ingester.IngestParquet(file, size, mapping)
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown format " + format})
+		return
+	}
+
+	if err != nil {
+		slog.Error("Error importing offers", "error", err, "created", ingester.Report.Created)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "report": ingester.Report})
+		return
+	}
+	c.JSON(http.StatusOK, ingester.Report)
+}
+
+func bufferToFile(r io.Reader) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "import-*.parquet")
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := io.Copy(file, r)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, err
+	}
+	return file, size, nil
+}
+
+func searchHandler(c *gin.Context) {
+	var request models.SearchRequest
+
+	if err := bindBody(c, &request); err != nil {
+		slog.Error("Error parsing request body", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query, err := request.Compile()
+	if err != nil {
+		slog.Error("Invalid search query", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offers, err := db.DB.Search(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	accesslog.SetResultCount(c, len(offers.Offers))
+	respond(c, http.StatusOK, offers)
+}
+
+// exportHandler streams all offers as json, ndjson or csv (format parameter).
+// The export can be paginated with page and pageSize, a pageSize of 0 returns all offers.
+func exportHandler(c *gin.Context) {
+	page, _ := strconv.ParseUint(c.Query("page"), 10, 64)
+	pageSize, _ := strconv.ParseUint(c.Query("pageSize"), 10, 64)
+
+	writer, contentType, ok := models.NewOfferWriter(c.Writer, c.Query("format"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown format " + c.Query("format")})
+		return
+	}
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+
+	skip := page * pageSize
+	written := uint64(0)
+	for offer := range db.DB.GetAllOffers(c.Request.Context()) {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if pageSize > 0 && written == pageSize {
+			break
+		}
+		if err := writer.Write(offer); err != nil {
+			slog.Error("Error exporting offers", "error", err)
+			return
+		}
+		written++
+	}
+	if err := writer.Close(); err != nil {
+		slog.Error("Error exporting offers", "error", err)
+	}
+	accesslog.SetResultCount(c, int(written))
+}
+
+func getOfferHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offer := db.DB.GetOffers(c.Request.Context(), id)[0]
+	if offer == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "offer not found"})
+		return
+	}
+	accesslog.SetResultCount(c, 1)
+	c.JSON(http.StatusOK, models.NewOfferDTO(offer, models.AllOfferFields))
+}
+
+func lookupHandler(c *gin.Context) {
+	var request models.LookupRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error parsing request body", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(request.IDs))
+	for _, idParam := range request.IDs {
+		id, err := uuid.Parse(idParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	response := models.LookupDTO{Offers: []*models.OfferDTO{}, NotFound: []string{}}
+	for i, offer := range db.DB.GetOffers(c.Request.Context(), ids...) {
+		if offer == nil {
+			response.NotFound = append(response.NotFound, request.IDs[i])
+			continue
+		}
+		response.Offers = append(response.Offers, models.NewOfferDTO(offer, models.AllOfferFields))
+	}
+	accesslog.SetResultCount(c, len(response.Offers))
+	c.JSON(http.StatusOK, response)
+}
+
+// bindBody decodes a MessagePack body if the content type says so and JSON otherwise
+func bindBody(c *gin.Context, obj any) error {
+	switch c.ContentType() {
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		return c.ShouldBindWith(obj, binding.MsgPack)
+	}
+	return c.ShouldBindJSON(obj)
+}
+
+// paramErrorHandler answers requests with parameters that do not match the spec
+func paramErrorHandler(c *gin.Context, err error, statusCode int) {
+	c.JSON(statusCode, gin.H{"error": err.Error()})
+}
+
+// respond encodes the response as MessagePack if the client accepts it and as JSON otherwise
+func respond(c *gin.Context, code int, obj any) {
+	_, span := tracing.Tracer.Start(c.Request.Context(), "encodeResponse")
+	defer span.End()
+
+	switch c.NegotiateFormat(binding.MIMEJSON, binding.MIMEMSGPACK, binding.MIMEMSGPACK2) {
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		c.Render(code, render.MsgPack{Data: obj})
+	default:
+		c.JSON(code, obj)
+	}
+}
+
+// widen converts an optional parameter of the narrower spec type to the uint64 of the models
+func widen[T uint8 | uint16](v *T) *uint64 {
+	if v == nil {
+		return nil
+	}
+	w := uint64(*v)
+	return &w
+}
+
+// queryList returns the values of a repeated or comma separated query parameter
+func queryList(c *gin.Context, key string) []string {
+	return splitList(c.QueryArray(key))
+}
+
+// splitList splits comma separated values and drops empty ones
+func splitList(params []string) []string {
+	var values []string
+	for _, param := range params {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// parseHistogramOptions reads the bucketing of a histogram from the query parameters with the given prefix:
+// <prefix>Width, <prefix>Boundaries (comma separated), <prefix>Mode (fixed, log or auto),
+// <prefix>Base (log, default 2), <prefix>Buckets (auto, default 10) and <prefix>Empty.
+func parseHistogramOptions(c *gin.Context, prefix string) models.HistogramOptions {
+	var options models.HistogramOptions
+	options.Width, _ = strconv.ParseUint(c.Query(prefix+"Width"), 10, 64)
+	options.Empty, _ = strconv.ParseBool(c.Query(prefix + "Empty"))
+
+	if boundariesParam := c.Query(prefix + "Boundaries"); boundariesParam != "" {
+		for _, b := range strings.Split(boundariesParam, ",") {
+			parsed, err := strconv.ParseUint(strings.TrimSpace(b), 10, 64)
+			if err != nil {
+				continue
+			}
+			options.Boundaries = append(options.Boundaries, parsed)
+		}
+		slices.Sort(options.Boundaries)
+		options.Boundaries = slices.Compact(options.Boundaries)
+	}
+
+	switch c.Query(prefix + "Mode") {
+	case "log":
+		options.LogBase = 2
+		if base, err := strconv.ParseUint(c.Query(prefix+"Base"), 10, 64); err == nil && base >= 2 {
+			options.LogBase = base
+		}
+	case "auto":
+		options.Auto = 10
+		if buckets, err := strconv.ParseUint(c.Query(prefix+"Buckets"), 10, 64); err == nil && buckets > 0 {
+			options.Auto = buckets
+		}
+	}
+
+	return options
+}
+
+func (server) CleanupData(c *gin.Context) {
 	db.DB.DeleteAllOffers(c.Request.Context())
 	c.String(http.StatusOK, "All offers deleted")
 }
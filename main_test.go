@@ -0,0 +1,623 @@
+package main
+
+import (
+	"bytes"
+	"check_republic/api"
+	"check_republic/db"
+	"check_republic/metrics"
+	"check_republic/models"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ugorji/go/codec"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const msPerDay = 24 * 60 * 60 * 1000
+
+func TestMain(m *testing.M) {
+	models.InitRegions()
+	models.InitCarTypes()
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// newTestServer serves the HTTP API over an empty database
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	db.InitMemoryDB()
+	ts := httptest.NewServer(newRouter(slog.New(slog.NewJSONHandler(io.Discard, nil))))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// TestContract checks that the handlers still implement the embedded spec. Sample offers are posted,
+// then every request and response of the spec operations is validated against the spec.
+func TestContract(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		t.Fatalf("loading spec: %v", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("building router: %v", err)
+	}
+	c := &contractChecker{t: t, server: newTestServer(t).URL, router: router}
+
+	offers := sampleOffers()
+	body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+	c.check(http.MethodPost, "/api/offers", nil, body)
+
+	for _, query := range sampleQueries() {
+		resp := c.check(http.MethodGet, "/api/offers", query, nil)
+		if resp == nil {
+			continue
+		}
+
+		// The narrower types of the spec must hold every value of the response
+		var result api.SearchResult
+		if err := json.Unmarshal(resp, &result); err != nil {
+			t.Errorf("GET /api/offers?%s: decoding response: %v", query.Encode(), err)
+			continue
+		}
+		pageSize, _ := strconv.ParseInt(query.Get("pageSize"), 10, 64)
+		if int64(len(result.Offers)) > pageSize {
+			t.Errorf("GET /api/offers?%s: %d offers on a page of size %d", query.Encode(), len(result.Offers), pageSize)
+		}
+		if meta := result.Meta; meta != nil {
+			page, _ := strconv.ParseInt(query.Get("page"), 10, 64)
+			expected := min(max(meta.Total-page*pageSize, 0), pageSize)
+			if int64(len(result.Offers)) != expected || meta.HasNext != ((page+1)*pageSize < meta.Total) {
+				t.Errorf("GET /api/offers?%s: %d offers and hasNext %v do not match %+v", query.Encode(), len(result.Offers), meta.HasNext, *meta)
+			}
+		}
+	}
+
+	c.check(http.MethodDelete, "/api/offers", nil, nil)
+}
+
+type contractChecker struct {
+	t      *testing.T
+	server string
+	router routers.Router
+}
+
+// check sends a request after validating it against the spec and validates the response.
+// It returns the response body or nil if the check failed.
+func (c *contractChecker) check(method string, path string, query url.Values, body []byte) []byte {
+	c.t.Helper()
+	ctx := context.Background()
+	name := method + " " + path + "?" + query.Encode()
+
+	target := c.server + path
+	if query != nil {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		c.t.Errorf("%s: %v", name, err)
+		return nil
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	route, pathParams, err := c.router.FindRoute(req)
+	if err != nil {
+		c.t.Errorf("%s: finding route: %v", name, err)
+		return nil
+	}
+	input := &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		c.t.Errorf("%s: invalid request: %v", name, err)
+		return nil
+	}
+	// Validating the request consumed the body
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Errorf("%s: %v", name, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.t.Errorf("%s: %v", name, err)
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		c.t.Errorf("%s: status %d: %s", name, resp.StatusCode, respBody)
+		return nil
+	}
+	respInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+	}
+	respInput.SetBodyBytes(respBody)
+	if err := openapi3filter.ValidateResponse(ctx, respInput); err != nil {
+		c.t.Errorf("%s: invalid response: %v", name, err)
+		return nil
+	}
+	return respBody
+}
+
+// sampleOffers creates offers in leaf regions of different subtrees with every car type
+func sampleOffers() []api.Offer {
+	carTypes := []string{"small", "sports", "luxury", "family"}
+	regions := []int32{114, 115, 116, 118, 119, 120}
+	start := int64(1732104000000)
+
+	offers := make([]api.Offer, 0, 48)
+	for i := 0; i < 48; i++ {
+		days := int64(1 + i%3)
+		offers = append(offers, api.Offer{
+			ID:                   uuid.New(),
+			Data:                 bytes.Repeat([]byte{byte(i)}, 256),
+			MostSpecificRegionID: regions[i%len(regions)],
+			StartDate:            start + int64(i%5)*msPerDay,
+			EndDate:              start + int64(i%5)*msPerDay + days*msPerDay,
+			NumberSeats:          uint8(2 + i%6),
+			Price:                uint16(1000 + 997*i),
+			CarType:              carTypes[i%len(carTypes)],
+			HasVollkasko:         i%2 == 0,
+			FreeKilometers:       uint16(50 * (i % 7)),
+		})
+	}
+	return offers
+}
+
+// baseQuery returns a search over the time range of the sample offers with the required parameters
+func baseQuery(regionID string, numberDays string, sortOrder string) url.Values {
+	return url.Values{
+		"regionID":              {regionID},
+		"timeRangeStart":        {"1732000000000"},
+		"timeRangeEnd":          {"1733000000000"},
+		"numberDays":            {numberDays},
+		"sortOrder":             {sortOrder},
+		"page":                  {"0"},
+		"pageSize":              {"10"},
+		"priceRangeWidth":       {"5000"},
+		"minFreeKilometerWidth": {"100"},
+	}
+}
+
+// sampleQueries covers both sort orders, pagination and every optional filter of the spec
+func sampleQueries() []url.Values {
+	queries := []url.Values{
+		baseQuery("0", "1", "price-asc"),
+		baseQuery("0", "2", "price-desc"),
+		baseQuery("5", "3", "price-asc"),
+		baseQuery("17", "1", "price-desc"),
+	}
+
+	paged := baseQuery("0", "2", "price-asc")
+	paged.Set("page", "1")
+	paged.Set("pageSize", "3")
+	queries = append(queries, paged)
+
+	filtered := baseQuery("0", "1", "price-asc")
+	filtered.Set("minNumberSeats", "4")
+	filtered.Set("minPrice", "2000")
+	filtered.Set("maxPrice", "40000")
+	filtered.Set("carType", "sports")
+	filtered.Set("onlyVollkasko", "true")
+	filtered.Set("minFreeKilometer", "100")
+	queries = append(queries, filtered)
+
+	empty := baseQuery("1", "1", "price-asc")
+	empty.Set("minPrice", "60000")
+	queries = append(queries, empty)
+
+	return queries
+}
+
+// TestSearchMessagePack checks that search documents encoded as MessagePack give the same results as JSON
+func TestSearchMessagePack(t *testing.T) {
+	ts := newTestServer(t)
+	offers := sampleOffers()
+	body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+	if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusOK {
+		t.Fatalf("posting offers: status %d", resp.status)
+	}
+
+	conditions := []map[string]any{
+		{"field": "numberSeats", "eq": 4},
+		{"field": "carType", "eq": "small"},
+		{"field": "carType", "in": []any{"sports", "family"}},
+		{"field": "hasVollkasko", "eq": true},
+		{"and": []any{
+			map[string]any{"field": "price", "gte": 2000},
+			map[string]any{"field": "freeKilometers", "in": []any{0, 100, 300}},
+		}},
+	}
+	for _, where := range conditions {
+		document := map[string]any{
+			"regionIDs":      []int{0},
+			"timeRangeStart": 1732000000000,
+			"timeRangeEnd":   1733000000000,
+			"numberDays":     1,
+			"pageSize":       100,
+			"where":          where,
+			"facets":         map[string]any{"carTypeCounts": true, "seatsCount": true},
+		}
+		jsonBody, _ := json.Marshal(document)
+		var msgpackBody []byte
+		if err := codec.NewEncoderBytes(&msgpackBody, new(codec.MsgpackHandle)).Encode(document); err != nil {
+			t.Fatal(err)
+		}
+
+		jsonResp := post(t, ts.URL+"/api/offers/search", "application/json", jsonBody)
+		msgpackResp := post(t, ts.URL+"/api/offers/search", "application/x-msgpack", msgpackBody)
+		if jsonResp.status != http.StatusOK || msgpackResp.status != http.StatusOK {
+			t.Errorf("where %s: status %d with JSON, %d with MessagePack: %s", jsonBody, jsonResp.status, msgpackResp.status, msgpackResp.body)
+			continue
+		}
+		if !bytes.Equal(jsonResp.body, msgpackResp.body) {
+			t.Errorf("where %v: MessagePack result %s, want %s", where, msgpackResp.body, jsonResp.body)
+		}
+	}
+}
+
+type response struct {
+	status int
+	body   []byte
+}
+
+// post sends a request body and reads the JSON response
+func post(t *testing.T, target string, contentType string, body []byte) response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response{status: resp.StatusCode, body: respBody}
+}
+
+// TestHistogramBucketLimit checks that histograms with too many empty buckets are rejected
+func TestHistogramBucketLimit(t *testing.T) {
+	ts := newTestServer(t)
+	offers := sampleOffers()[:2]
+	offers[0].Price, offers[1].Price = 1, 60000
+	offers[1].EndDate = offers[1].StartDate + msPerDay
+	body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+	if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusOK {
+		t.Fatalf("posting offers: status %d", resp.status)
+	}
+
+	query := baseQuery("0", "1", "price-asc")
+	query.Set("priceRangeWidth", "1")
+	query.Set("priceRangeEmpty", "true")
+	resp, err := http.Get(ts.URL + "/api/offers?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	query.Set("priceRangeWidth", "10")
+	resp, err = http.Get(ts.URL + "/api/offers?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status %d with 6000 buckets, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+// TestDocsAssets checks that the explorer only loads files served by the server
+func TestDocsAssets(t *testing.T) {
+	ts := newTestServer(t)
+
+	assets := regexp.MustCompile(`(?:href|src)="([^"]+)"`).FindAllStringSubmatch(explorerPage, -1)
+	if len(assets) == 0 {
+		t.Fatal("the explorer page loads no assets")
+	}
+	for _, asset := range assets {
+		resp, err := http.Get(ts.URL + asset[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s: status %d", asset[1], resp.StatusCode)
+		}
+	}
+}
+
+func TestUnselectedFacets(t *testing.T) {
+	ts := newTestServer(t)
+
+	// No offer spans 30 days, so the selected aggregations are empty
+	query := baseQuery("0", "30", "price-asc")
+	query.Set("priceRangeWidth", "10")
+	query.Set("facets", "priceRanges,seatsCount")
+	resp, err := http.Get(ts.URL + "/api/offers?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	var result map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"priceRanges", "seatsCount"} {
+		if got := string(result[key]); got != "[]" {
+			t.Errorf("selected %s is %q, want []", key, got)
+		}
+	}
+	for _, key := range []string{"carTypeCounts", "freeKilometerRange", "vollkaskoCount"} {
+		if got, ok := result[key]; ok {
+			t.Errorf("unselected %s is in the response: %s", key, got)
+		}
+	}
+}
+
+func TestCreateOffersRejectsDuplicates(t *testing.T) {
+	ts := newTestServer(t)
+	offers := sampleOffers()
+	body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+	if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusOK {
+		t.Fatalf("posting offers: status %d", resp.status)
+	}
+
+	// Neither an existing ID nor an ID repeated in the batch creates any offer of the batch
+	fresh := sampleOffers()[0]
+	moved := offers[0]
+	moved.MostSpecificRegionID = offers[1].MostSpecificRegionID
+	for _, batch := range [][]api.Offer{{fresh, moved}, {fresh, fresh}} {
+		body, _ = json.Marshal(api.CreateOffersJSONRequestBody{Offers: &batch})
+		if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusConflict {
+			t.Errorf("posting a duplicate ID: status %d, want %d", resp.status, http.StatusConflict)
+		}
+	}
+
+	for _, regionID := range []int32{0, 114, 115} {
+		want := 0
+		for _, o := range offers {
+			if o.EndDate-o.StartDate == msPerDay && (regionID == 0 || o.MostSpecificRegionID == regionID) {
+				want++
+			}
+		}
+		if got := countOffers(t, ts, regionID); got != want {
+			t.Errorf("%d one day offers in region %d, want %d", got, regionID, want)
+		}
+	}
+}
+
+// countOffers returns the number of one day offers in a region
+func countOffers(t *testing.T, ts *httptest.Server, regionID int32) int {
+	query := baseQuery(strconv.Itoa(int(regionID)), "1", "price-asc")
+	query.Set("pageSize", "100")
+	resp, err := http.Get(ts.URL + "/api/offers?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var dto models.DTO
+	if err := json.NewDecoder(resp.Body).Decode(&dto); err != nil {
+		t.Fatal(err)
+	}
+	return len(dto.Offers)
+}
+
+func TestUnknownTimeZone(t *testing.T) {
+	ts := newTestServer(t)
+
+	query := baseQuery("0", "1", "price-asc")
+	query.Set("startDateInterval", "day")
+	for timeZone, want := range map[string]int{"UTC": http.StatusOK, "Mars/Olympus_Mons": http.StatusBadRequest} {
+		query.Set("timeZone", timeZone)
+		resp, err := http.Get(ts.URL + "/api/offers?" + query.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("time zone %s: status %d, want %d", timeZone, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestPageOverflow(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, page := range []string{`9223372036854775808`, `9223372036854775807`} {
+		body := []byte(`{"regionIDs": [0], "timeRangeStart": 0, "timeRangeEnd": 1733000000000, "numberDays": 1, "page": ` + page + `, "pageSize": 2}`)
+		if resp := post(t, ts.URL+"/api/offers/search", "application/json", body); resp.status != http.StatusBadRequest {
+			t.Errorf("page %s of size 2: status %d, want %d", page, resp.status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestMetricsObservePanics(t *testing.T) {
+	r := newRouter(slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	r.GET("/panic", func(c *gin.Context) { panic("test") })
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// The gzip middleware writes the status of compressed responses before the recovery
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := client.Get(ts.URL + "/panic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+
+	resp, err = client.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !regexp.MustCompile(`_count\{method="GET",route="/panic",status="500"\} 1`).Match(body) {
+		t.Error("the request that panicked is not observed")
+	}
+}
+
+func TestBulkProgress(t *testing.T) {
+	ts := newTestServer(t)
+
+	var body bytes.Buffer
+	offers := sampleOffers()
+	for i := range 20001 {
+		offer := offers[i%len(offers)]
+		offer.ID = uuid.New()
+		line, _ := json.Marshal(offer)
+		body.Write(append(line, '\n'))
+	}
+	body.WriteString("{\n")
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/offers/bulk", &body)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Accept", "application/x-ndjson")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var reports []models.IngestReport
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var report models.IngestReport
+		if err := decoder.Decode(&report); err != nil {
+			t.Fatal(err)
+		}
+		reports = append(reports, report)
+	}
+
+	want := [][3]uint64{{10000, 10000, 0}, {20000, 20000, 0}, {20002, 20001, 1}}
+	if len(reports) != len(want) {
+		t.Fatalf("%d reports, want %d", len(reports), len(want))
+	}
+	for i, report := range reports {
+		if got := [3]uint64{report.Lines, report.Created, report.Failed}; got != want[i] {
+			t.Errorf("report %d has lines, created and failed %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestSearchCache(t *testing.T) {
+	ts := newTestServer(t)
+	hits := func() float64 { return testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("hit")) }
+
+	before := hits()
+	first := countOffers(t, ts, 0)
+	if countOffers(t, ts, 0) != first || hits() != before+1 {
+		t.Errorf("the repeated search is not answered from the cache")
+	}
+
+	// Writes invalidate the cached results
+	offers := sampleOffers()
+	body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+	if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusOK {
+		t.Fatalf("posting offers: status %d", resp.status)
+	}
+	if got := countOffers(t, ts, 0); got == first || hits() != before+1 {
+		t.Errorf("%d offers after posting, the cached result is returned", got)
+	}
+}
+
+func TestSearchSpans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	// The router picks up the provider when it is created
+	ts := newTestServer(t)
+	countOffers(t, ts, 0)
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spans.Ended() {
+		byName[span.Name()] = span
+	}
+	root, ok := byName["/api/offers"]
+	if !ok {
+		t.Fatalf("no span for the handler, got %v", slices.Collect(maps.Keys(byName)))
+	}
+
+	parents := map[string]string{
+		"parseParameters":    "/api/offers",
+		"MemoryDB.Search":    "/api/offers",
+		"offersInRegions":    "MemoryDB.Search",
+		"FilterMandatory":    "MemoryDB.Search",
+		"FilterAggregations": "MemoryDB.Search",
+		"SortOffers":         "MemoryDB.Search",
+		"buildDTO":           "MemoryDB.Search",
+		"encodeResponse":     "/api/offers",
+	}
+	for name, parentName := range parents {
+		span, ok := byName[name]
+		if !ok {
+			t.Errorf("no span %s", name)
+			continue
+		}
+		parent, ok := byName[parentName]
+		if !ok {
+			continue
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() || span.SpanContext().TraceID() != root.SpanContext().TraceID() {
+			t.Errorf("span %s is not a child of %s", name, parentName)
+		}
+	}
+}
+
+func TestCreateOffersRejectsUnknownRegions(t *testing.T) {
+	ts := newTestServer(t)
+
+	// Region 0 is the root and 99999 is not in the tree, offers in them would never be found
+	for _, regionID := range []int32{0, 99999} {
+		offers := sampleOffers()[:1]
+		offers[0].MostSpecificRegionID = regionID
+		body, _ := json.Marshal(api.CreateOffersJSONRequestBody{Offers: &offers})
+		if resp := post(t, ts.URL+"/api/offers", "application/json", body); resp.status != http.StatusBadRequest {
+			t.Errorf("offer in region %d: status %d, want %d", regionID, resp.status, http.StatusBadRequest)
+		}
+	}
+}
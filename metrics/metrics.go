@@ -0,0 +1,74 @@
+// Package metrics holds the Prometheus metrics of the server. The default registry also exports the Go runtime and process metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "checkrepublic"
+
+var (
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by route.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 18),
+	}, []string{"method", "route", "status"})
+
+	OffersIngested = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "offers_ingested_total",
+		Help:      "Number of offers stored in the database.",
+	})
+
+	OffersPerRegion = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "region_offers",
+		Help:      "Number of offers in a region including its sub regions.",
+	}, []string{"region"})
+
+	SearchMatches = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "search_matching_offers",
+		Help:      "Number of offers matching all filters of a search.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 12),
+	})
+
+	SearchResults = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "search_result_offers",
+		Help:      "Number of offers on the returned page of a search.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "search_cache_requests_total",
+		Help:      "Number of searches answered from the result cache (hit) or computed (miss).",
+	}, []string{"result"})
+)
+
+// Middleware observes the latency of every request by its route pattern
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		RequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the metrics of the default registry
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+)
+
+// SpecCarTypes are the car types defined by the challenge spec
+var SpecCarTypes = []string{"small", "sports", "luxury", "family"}
+
+// CarTypes is the catalogue of accepted car types, configured with CAR_TYPES (comma separated)
+var CarTypes = SpecCarTypes
+
+// CarTypeCompat restricts the carTypeCounts in responses to the spec car types, configured with CAR_TYPES_COMPAT
+var CarTypeCompat = false
+
+var carTypeSet = toSet(SpecCarTypes)
+
+func InitCarTypes() {
+	if env := os.Getenv("CAR_TYPES"); env != "" {
+		types := make([]string, 0)
+		for _, t := range strings.Split(env, ",") {
+			t = strings.TrimSpace(t)
+			if t != "" {
+				types = append(types, t)
+			}
+		}
+		CarTypes = types
+	}
+	carTypeSet = toSet(CarTypes)
+	CarTypeCompat = os.Getenv("CAR_TYPES_COMPAT") == "true"
+
+	slog.Info("Car types loaded", "carTypes", CarTypes, "compat", CarTypeCompat)
+}
+
+func IsValidCarType(carType string) bool {
+	_, ok := carTypeSet[carType]
+	return ok
+}
+
+// Validate checks that the offer only uses values known to the service. The region has to be a leaf
+// of the region tree, offers in other regions would not be found by searches and exports.
+func (offer *Offer) Validate() error {
+	if !IsValidCarType(offer.CarType) {
+		return fmt.Errorf("unknown car type %q", offer.CarType)
+	}
+	if _, ok := SpecificRegionToAnchestor[int32(offer.MostSpecificRegionID)]; !ok || offer.MostSpecificRegionID > math.MaxInt32 {
+		return fmt.Errorf("unknown leaf region %d", offer.MostSpecificRegionID)
+	}
+	return nil
+}
+
+// Validate checks every offer and reports the index of the first invalid one
+func (offers *Offers) Validate() error {
+	for i, offer := range offers.Offers {
+		if err := offer.Validate(); err != nil {
+			return fmt.Errorf("offer %d (%s): %w", i, offer.ID, err)
+		}
+	}
+	return nil
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -2,101 +2,28 @@ package models
 
 import "sort"
 
-// PriceRange represents the price range details.
+// HistogramRange represents the count of offers in a range of values.
 type HistogramRange struct {
 	Start uint64 `json:"start"`
 	End   uint64 `json:"end"`
 	Count uint64 `json:"count"`
 }
 
-type Bucket HistogramRange
-
-func BucketizeOffersByPrice(offers []*Offer, width uint32) []Bucket {
-	if width == 0 {
-		panic("Width must be greater than 0")
-	}
-
-	bucketMap := make(map[uint32]*Bucket)
-
-	// Distribute offers into buckets
-	for _, offer := range offers {
-		start := uint32(offer.Price) / width * width
-		end := start + width
-
-		if _, exists := bucketMap[start]; !exists {
-			bucketMap[start] = &Bucket{Start: uint64(start), End: uint64(end)}
-		}
-		bucketMap[start].Count++
-	}
-
-	// Create a slice from the map and filter out empty buckets
-	var buckets []Bucket
-	for _, bucket := range bucketMap {
-		if bucket.Count > 0 {
-			buckets = append(buckets, *bucket)
-		}
-	}
-
-	// Sort buckets by start ascending
-	sort.Slice(buckets, func(i, j int) bool {
-		return buckets[i].Start < buckets[j].Start
-	})
-
-	return buckets
-}
-
-func BucketizeOffersByKilometer(offers []*Offer, width uint32) []Bucket {
-	if width == 0 {
-		panic("Width must be greater than 0")
-	}
-
-	bucketMap := make(map[uint32]*Bucket)
-
-	// Distribute offers into buckets
-	for _, offer := range offers {
-		start := uint32(offer.FreeKilometers) / width * width
-		end := start + width
-
-		if _, exists := bucketMap[start]; !exists {
-			bucketMap[start] = &Bucket{Start: uint64(start), End: uint64(end)}
-		}
-		bucketMap[start].Count++
-	}
+// CarTypeCount represents the count of offers by car type.
+type CarTypeCount map[string]uint64
 
-	// Create a slice from the map and filter out empty buckets
-	var buckets []Bucket
-	for _, bucket := range bucketMap {
-		if bucket.Count > 0 {
-			buckets = append(buckets, *bucket)
-		}
+// NewCarTypeCount creates the count of every car type of the catalogue, or only the spec car types in compatibility mode.
+func NewCarTypeCount(counts map[string]uint64) CarTypeCount {
+	types := CarTypes
+	if CarTypeCompat {
+		types = SpecCarTypes
 	}
 
-	// Sort buckets by start ascending
-	sort.Slice(buckets, func(i, j int) bool {
-		return buckets[i].Start < buckets[j].Start
-	})
-
-	return buckets
-}
-
-// CarTypeCount represents the count of offers by car type.
-type CarTypeCount struct {
-	Small  uint64 `json:"small"`
-	Sports uint64 `json:"sports"`
-	Luxury uint64 `json:"luxury"`
-	Family uint64 `json:"family"`
-}
-
-func (c *CarTypeCount) Add(carType string) {
-	if carType == "small" {
-		c.Small++
-	} else if carType == "sports" {
-		c.Sports++
-	} else if carType == "luxury" {
-		c.Luxury++
-	} else if carType == "family" {
-		c.Family++
+	c := make(CarTypeCount, len(types))
+	for _, t := range types {
+		c[t] = counts[t]
 	}
+	return c
 }
 
 // VollkaskoCount represents the count of offers with and without Vollkasko.
@@ -105,14 +32,6 @@ type VollkaskoCount struct {
 	FalseCount uint64 `json:"falseCount"`
 }
 
-func (v *VollkaskoCount) Add(hasVollkasko bool) {
-	if hasVollkasko {
-		v.TrueCount++
-	} else {
-		v.FalseCount++
-	}
-}
-
 // SeatsCount represents the count of offers by number of seats.
 type KVSeatsCount struct {
 	NumberSeats uint64 `json:"numberSeats"`
@@ -121,10 +40,48 @@ type KVSeatsCount struct {
 
 type SeatsSummary map[uint64]*KVSeatsCount
 
-func (seats *SeatsSummary) Add(numberSeats uint64) {
-	if s, ok := (*seats)[numberSeats]; ok {
-		s.Count++
-	} else {
-		(*seats)[numberSeats] = &KVSeatsCount{NumberSeats: numberSeats, Count: 1}
+// KVSubRegionCount represents the count of offers in a direct subregion of the queried region.
+type KVSubRegionCount struct {
+	RegionID int32  `json:"regionID"`
+	Name     string `json:"name"`
+	Count    uint64 `json:"count"`
+}
+
+// SubRegionSummary counts offers by the direct subregion of a parent region they belong to.
+type SubRegionSummary struct {
+	RegionID int32
+	Counts   map[int32]*KVSubRegionCount
+}
+
+// NewSubRegionSummary creates an empty summary holding a zero count for every direct subregion of regionID.
+func NewSubRegionSummary(regionID int32) *SubRegionSummary {
+	s := &SubRegionSummary{RegionID: regionID, Counts: make(map[int32]*KVSubRegionCount)}
+	if region, ok := RegionsByID[regionID]; ok {
+		for _, sub := range region.SubRegions {
+			s.Counts[sub.Id] = &KVSubRegionCount{RegionID: sub.Id, Name: sub.Name}
+		}
+	}
+	return s
+}
+
+func (s *SubRegionSummary) Add(specificRegionID int32) {
+	child, ok := SubRegionOf(s.RegionID, specificRegionID)
+	if !ok {
+		return
 	}
+	if c, ok := s.Counts[child]; ok {
+		c.Count++
+	}
+}
+
+// Slice returns the counts sorted by region id ascending.
+func (s *SubRegionSummary) Slice() []*KVSubRegionCount {
+	ret := make([]*KVSubRegionCount, 0, len(s.Counts))
+	for _, v := range s.Counts {
+		ret = append(ret, v)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].RegionID < ret[j].RegionID
+	})
+	return ret
 }
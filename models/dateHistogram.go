@@ -0,0 +1,78 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// DateHistogramOptions configures the calendar buckets of a date histogram.
+type DateHistogramOptions struct {
+	// One of IntervalDay, IntervalWeek (starting on monday) or IntervalMonth
+	Interval string
+	// Time zone the buckets are aligned to, UTC if nil
+	Location *time.Location
+}
+
+func (o DateHistogramOptions) Enabled() bool {
+	return o.Interval == IntervalDay || o.Interval == IntervalWeek || o.Interval == IntervalMonth
+}
+
+// DateHistogram counts offers in calendar buckets of a timestamp in ms since UNIX epoch.
+type DateHistogram struct {
+	Value   func(offer *Offer) uint64
+	Options DateHistogramOptions
+
+	counts map[int64]*HistogramRange
+}
+
+func NewDateHistogram(value func(offer *Offer) uint64, options DateHistogramOptions) *DateHistogram {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	return &DateHistogram{Value: value, Options: options, counts: make(map[int64]*HistogramRange)}
+}
+
+func (h *DateHistogram) Add(offer *Offer) {
+	t := time.UnixMilli(int64(h.Value(offer))).In(h.Options.Location)
+
+	var start, end time.Time
+	switch h.Options.Interval {
+	case IntervalDay:
+		start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		end = start.AddDate(0, 0, 1)
+	case IntervalWeek:
+		start = time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+		end = start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		end = start.AddDate(0, 1, 0)
+	default:
+		return
+	}
+
+	// The first bucket of a zone ahead of UTC starts before the epoch, it is clamped to the epoch
+	key := max(start.UnixMilli(), 0)
+	if b, ok := h.counts[key]; ok {
+		b.Count++
+	} else {
+		h.counts[key] = &HistogramRange{Start: uint64(key), End: uint64(end.UnixMilli()), Count: 1}
+	}
+}
+
+// Buckets returns the non empty buckets sorted by start ascending.
+func (h *DateHistogram) Buckets() []HistogramRange {
+	buckets := make([]HistogramRange, 0, len(h.counts))
+	for _, b := range h.counts {
+		buckets = append(buckets, *b)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start < buckets[j].Start
+	})
+	return buckets
+}
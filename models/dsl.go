@@ -0,0 +1,409 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// SearchRequest is the JSON query document of POST /api/offers/search.
+type SearchRequest struct {
+	RegionIDs      []int32        `json:"regionIDs"`
+	TimeRangeStart uint64         `json:"timeRangeStart"`
+	TimeRangeEnd   uint64         `json:"timeRangeEnd"`
+	NumberDays     uint64         `json:"numberDays"`
+	Where          *Condition     `json:"where,omitempty"`
+	Sort           []SortKey      `json:"sort,omitempty"`
+	Page           uint64         `json:"page"`
+	PageSize       uint64         `json:"pageSize"`
+	Facets         FacetSelection `json:"facets"`
+	Fields         []string       `json:"fields,omitempty"`
+}
+
+// Condition is a node of the boolean filter expression. Either one of And, Or and Not is set,
+// or Field with at least one comparison. Multiple comparisons on a field must all hold.
+type Condition struct {
+	And []*Condition `json:"and,omitempty"`
+	Or  []*Condition `json:"or,omitempty"`
+	Not *Condition   `json:"not,omitempty"`
+
+	Field string  `json:"field,omitempty"`
+	Eq    any     `json:"eq,omitempty"`
+	In    []any   `json:"in,omitempty"`
+	Gt    *uint64 `json:"gt,omitempty"`
+	Gte   *uint64 `json:"gte,omitempty"`
+	Lt    *uint64 `json:"lt,omitempty"`
+	Lte   *uint64 `json:"lte,omitempty"`
+}
+
+// FacetSelection selects the aggregations of the response, unselected aggregations are not computed.
+type FacetSelection struct {
+	PriceRanges        *HistogramOptions       `json:"priceRanges,omitempty"`
+	FreeKilometerRange *HistogramOptions       `json:"freeKilometerRange,omitempty"`
+	PricePerDayRanges  *HistogramOptions       `json:"pricePerDayRanges,omitempty"`
+	StartDateRanges    *DateHistogramSelection `json:"startDateRanges,omitempty"`
+	CarTypeCounts      bool                    `json:"carTypeCounts"`
+	VollkaskoCount     bool                    `json:"vollkaskoCount"`
+	SeatsCount         bool                    `json:"seatsCount"`
+	SubRegionCounts    bool                    `json:"subRegionCounts"`
+	Stats              bool                    `json:"stats"`
+}
+
+type DateHistogramSelection struct {
+	Interval string `json:"interval"`
+	TimeZone string `json:"timeZone"`
+}
+
+// facetFields maps the fields with a facet to the filter of the facet
+var facetFields = map[string]func(f *Filters) *Predicate{
+	"price":          func(f *Filters) *Predicate { return &f.Price },
+	"pricePerDay":    func(f *Filters) *Predicate { return &f.PricePerDay },
+	"freeKilometers": func(f *Filters) *Predicate { return &f.FreeKilometers },
+	"carType":        func(f *Filters) *Predicate { return &f.CarType },
+	"hasVollkasko":   func(f *Filters) *Predicate { return &f.Vollkasko },
+	"numberSeats":    func(f *Filters) *Predicate { return &f.NumberSeats },
+}
+
+// Compile validates the request and compiles it to a SearchQuery.
+// Top level conditions on a single faceted field become the filter of that facet, so the
+// facet still shows counts for the values that are not selected. All other conditions
+// filter the offers before any aggregation.
+func (r *SearchRequest) Compile() (*SearchQuery, error) {
+	if len(r.RegionIDs) == 0 {
+		return nil, errors.New("at least one region is required")
+	}
+	for _, id := range r.RegionIDs {
+		if _, ok := RegionsByID[id]; !ok {
+			return nil, fmt.Errorf("unknown region %d", id)
+		}
+	}
+
+	q := &SearchQuery{
+		RegionIDs:      r.RegionIDs,
+		TimeRangeStart: r.TimeRangeStart,
+		TimeRangeEnd:   r.TimeRangeEnd,
+		NumberDays:     r.NumberDays,
+		Sort:           r.Sort,
+		Page:           r.Page,
+		PageSize:       r.PageSize,
+		Fields:         NewOfferFields(r.Fields),
+	}
+	if q.TimeRangeEnd == 0 {
+		q.TimeRangeEnd = math.MaxUint64
+	}
+
+	for _, key := range r.Sort {
+		if _, ok := NumericAttributes[key.Field]; !ok {
+			return nil, fmt.Errorf("cannot sort on field %q", key.Field)
+		}
+	}
+
+	if r.Where != nil {
+		conjuncts := []*Condition{r.Where}
+		if len(r.Where.And) > 0 {
+			conjuncts = r.Where.And
+		}
+
+		var where []Predicate
+		for _, c := range conjuncts {
+			p, err := c.Compile()
+			if err != nil {
+				return nil, err
+			}
+			fields := c.fields()
+			if facet, ok := facetFields[fields[0]]; ok && len(fields) == 1 {
+				filter := facet(&q.Filters)
+				*filter = and(*filter, p)
+			} else {
+				where = append(where, p)
+			}
+		}
+		q.Filters.Where = and(where...)
+	}
+
+	f := r.Facets
+	q.Aggregations = AggregationOptions{
+		CarTypeCounts:  f.CarTypeCounts,
+		VollkaskoCount: f.VollkaskoCount,
+		SeatsCount:     f.SeatsCount,
+		Stats:          f.Stats,
+	}
+	q.Aggregations.PriceRanges = f.PriceRanges
+	q.Aggregations.FreeKilometerRanges = f.FreeKilometerRange
+	if f.PricePerDayRanges != nil {
+		q.Aggregations.PricePerDayRanges = *f.PricePerDayRanges
+	}
+	if f.StartDateRanges != nil {
+		location, err := time.LoadLocation(f.StartDateRanges.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", f.StartDateRanges.TimeZone)
+		}
+		q.Aggregations.StartDateRanges = DateHistogramOptions{Interval: f.StartDateRanges.Interval, Location: location}
+		if !q.Aggregations.StartDateRanges.Enabled() {
+			return nil, fmt.Errorf("unknown interval %q", f.StartDateRanges.Interval)
+		}
+	}
+	if f.SubRegionCounts {
+		if len(r.RegionIDs) != 1 {
+			return nil, errors.New("subregion counts require exactly one region")
+		}
+		q.Aggregations.BreakdownRegion = &r.RegionIDs[0]
+	}
+
+	return q, nil
+}
+
+// Compile compiles the condition to a predicate, it is never nil.
+func (c *Condition) Compile() (Predicate, error) {
+	kinds := 0
+	for _, set := range []bool{len(c.And) > 0, len(c.Or) > 0, c.Not != nil, c.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds > 1 {
+		return nil, errors.New("a condition sets only one of and, or, not and field")
+	}
+	if c.Field == "" && (c.Eq != nil || c.In != nil || c.Gt != nil || c.Gte != nil || c.Lt != nil || c.Lte != nil) {
+		return nil, errors.New("comparisons require a field")
+	}
+
+	switch {
+	case len(c.And) > 0:
+		ps, err := compileAll(c.And)
+		if err != nil {
+			return nil, err
+		}
+		return and(ps...), nil
+	case len(c.Or) > 0:
+		ps, err := compileAll(c.Or)
+		if err != nil {
+			return nil, err
+		}
+		return func(o *Offer) bool {
+			for _, p := range ps {
+				if p(o) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case c.Not != nil:
+		p, err := c.Not.Compile()
+		if err != nil {
+			return nil, err
+		}
+		return func(o *Offer) bool { return !p(o) }, nil
+	case c.Field != "":
+		return c.compileField()
+	}
+	return nil, errors.New("empty condition")
+}
+
+func (c *Condition) compileField() (Predicate, error) {
+	var ps []Predicate
+
+	if value, ok := NumericAttributes[c.Field]; ok {
+		values, err := c.values(func(v any) (any, bool) { u, ok := toUint64(v); return u, ok })
+		if err != nil {
+			return nil, err
+		}
+		if values != nil {
+			set := make([]uint64, 0, len(values))
+			for _, v := range values {
+				set = append(set, v.(uint64))
+			}
+			ps = append(ps, setFilter(value, set))
+		}
+		if c.Gt != nil {
+			gt := *c.Gt
+			ps = append(ps, func(o *Offer) bool { return value(o) > gt })
+		}
+		if c.Gte != nil {
+			gte := *c.Gte
+			ps = append(ps, func(o *Offer) bool { return value(o) >= gte })
+		}
+		if c.Lt != nil {
+			lt := *c.Lt
+			ps = append(ps, func(o *Offer) bool { return value(o) < lt })
+		}
+		if c.Lte != nil {
+			lte := *c.Lte
+			ps = append(ps, func(o *Offer) bool { return value(o) <= lte })
+		}
+	} else {
+		if c.Gt != nil || c.Gte != nil || c.Lt != nil || c.Lte != nil {
+			return nil, fmt.Errorf("field %q does not support range comparisons", c.Field)
+		}
+
+		switch c.Field {
+		case "carType":
+			values, err := c.values(func(v any) (any, bool) { s, ok := toString(v); return s, ok })
+			if err != nil {
+				return nil, err
+			}
+			if values != nil {
+				carTypes := make([]string, 0, len(values))
+				for _, v := range values {
+					carTypes = append(carTypes, v.(string))
+				}
+				ps = append(ps, carTypeFilter(carTypes))
+			}
+		case "hasVollkasko":
+			values, err := c.values(func(v any) (any, bool) { b, ok := v.(bool); return b, ok })
+			if err != nil {
+				return nil, err
+			}
+			if values != nil {
+				var allowTrue, allowFalse bool
+				for _, v := range values {
+					if v.(bool) {
+						allowTrue = true
+					} else {
+						allowFalse = true
+					}
+				}
+				ps = append(ps, func(o *Offer) bool { return (o.HasVollkasko && allowTrue) || (!o.HasVollkasko && allowFalse) })
+			}
+		default:
+			return nil, fmt.Errorf("unknown field %q", c.Field)
+		}
+	}
+
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("no comparison for field %q", c.Field)
+	}
+	return and(ps...), nil
+}
+
+// toUint64 converts a non-negative whole number. JSON decodes numbers as float64,
+// MessagePack as the integer kind of the encoding.
+func toUint64(v any) (uint64, bool) {
+	switch n := v.(type) {
+	case float64:
+		if n < 0 || n != math.Trunc(n) {
+			return 0, false
+		}
+		return uint64(n), true
+	case float32:
+		return toUint64(float64(n))
+	case int:
+		return uint64(n), n >= 0
+	case int8:
+		return uint64(n), n >= 0
+	case int16:
+		return uint64(n), n >= 0
+	case int32:
+		return uint64(n), n >= 0
+	case int64:
+		return uint64(n), n >= 0
+	case uint:
+		return uint64(n), true
+	case uint8:
+		return uint64(n), true
+	case uint16:
+		return uint64(n), true
+	case uint32:
+		return uint64(n), true
+	case uint64:
+		return n, true
+	}
+	return 0, false
+}
+
+// toString converts a string, MessagePack may decode strings as bytes
+func toString(v any) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
+
+// values returns the converted values of eq and in, nil if neither is set. An empty in is rejected,
+// it would match no offer.
+func (c *Condition) values(convert func(v any) (any, bool)) ([]any, error) {
+	if c.In != nil && len(c.In) == 0 {
+		return nil, fmt.Errorf("empty in for field %q", c.Field)
+	}
+	raw := c.In
+	if c.Eq != nil {
+		raw = append([]any{c.Eq}, raw...)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	values := make([]any, 0, len(raw))
+	for _, v := range raw {
+		converted, ok := convert(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v for field %q", v, c.Field)
+		}
+		values = append(values, converted)
+	}
+	return values, nil
+}
+
+// fields returns the distinct fields the condition refers to
+func (c *Condition) fields() []string {
+	seen := map[string]struct{}{}
+	var walk func(c *Condition)
+	walk = func(c *Condition) {
+		if c.Field != "" {
+			seen[c.Field] = struct{}{}
+		}
+		for _, sub := range append(append([]*Condition{}, c.And...), c.Or...) {
+			walk(sub)
+		}
+		if c.Not != nil {
+			walk(c.Not)
+		}
+	}
+	walk(c)
+
+	fields := make([]string, 0, len(seen))
+	for f := range seen {
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func compileAll(conditions []*Condition) ([]Predicate, error) {
+	ps := make([]Predicate, 0, len(conditions))
+	for _, c := range conditions {
+		p, err := c.Compile()
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// and combines predicates, nil predicates are ignored
+func and(predicates ...Predicate) Predicate {
+	ps := make([]Predicate, 0, len(predicates))
+	for _, p := range predicates {
+		if p != nil {
+			ps = append(ps, p)
+		}
+	}
+	switch len(ps) {
+	case 0:
+		return nil
+	case 1:
+		return ps[0]
+	}
+	return func(o *Offer) bool {
+		for _, p := range ps {
+			if !p(o) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConditionCompile(t *testing.T) {
+	offers := []*Offer{
+		{CarType: "small", Price: 1},
+		{CarType: "family", Price: 5},
+	}
+
+	tests := []struct {
+		condition string
+		err       string
+		matches   int
+	}{
+		{condition: `{"or": [{"field": "carType", "in": []}, {"field": "price", "gt": 1}]}`, err: "empty in"},
+		{condition: `{"not": {"field": "numberSeats", "in": []}}`, err: "empty in"},
+		{condition: `{"field": "carType", "in": []}`, err: "empty in"},
+		{condition: `{"field": "price", "and": [{"field": "price", "gt": 1}]}`, err: "only one of"},
+		{condition: `{"field": "price", "gt": 1, "or": [{"field": "carType", "eq": "small"}]}`, err: "only one of"},
+		{condition: `{"and": [{"field": "price", "gt": 1}], "gt": 1}`, err: "require a field"},
+		{condition: `{"field": "carType"}`, err: "no comparison"},
+		{condition: `{"field": "hasVollkasko"}`, err: "no comparison"},
+		{condition: `{"or": [{"field": "carType", "in": ["small"]}, {"field": "price", "gt": 1}]}`, matches: 2},
+		{condition: `{"not": {"field": "carType", "in": ["small"]}}`, matches: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			var c Condition
+			if err := json.Unmarshal([]byte(tt.condition), &c); err != nil {
+				t.Fatal(err)
+			}
+			p, err := c.Compile()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Compile() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			matches := 0
+			for _, o := range offers {
+				if p(o) {
+					matches++
+				}
+			}
+			if matches != tt.matches {
+				t.Errorf("%d offers match, want %d", matches, tt.matches)
+			}
+		})
+	}
+}
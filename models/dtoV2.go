@@ -0,0 +1,79 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// DTOV2 is the version 2 search response. Facets are typed lists instead of the fixed shapes of the spec
+// and the metadata says how many offers matched.
+type DTOV2 struct {
+	Offers []*OfferDTO `json:"offers"`
+	Facets FacetsV2    `json:"facets"`
+	Meta   MetaV2      `json:"meta"`
+}
+
+// FacetsV2 holds the aggregations of a search by facet name, the facets that are not selected are left out.
+// The car type and Vollkasko buckets always list every value, so they are only empty if not selected.
+type FacetsV2 struct {
+	Price          *[]HistogramRange     `json:"price,omitempty"`
+	FreeKilometers *[]HistogramRange     `json:"freeKilometers,omitempty"`
+	PricePerDay    *[]HistogramRange     `json:"pricePerDay,omitempty"`
+	StartDate      *[]HistogramRange     `json:"startDate,omitempty"`
+	CarType        []TermBucket[string]  `json:"carType,omitempty"`
+	NumberSeats    *[]TermBucket[uint64] `json:"numberSeats,omitempty"`
+	Vollkasko      []TermBucket[bool]    `json:"vollkasko,omitempty"`
+	SubRegion      []*KVSubRegionCount   `json:"subRegion,omitempty"`
+	Stats          *StatsSummary         `json:"stats,omitempty"`
+}
+
+// TermBucket is the number of offers with a value of an attribute
+type TermBucket[K comparable] struct {
+	Value K      `json:"value"`
+	Count uint64 `json:"count"`
+}
+
+// MetaV2 describes the page of a search and how long it took
+type MetaV2 struct {
+	PageMeta
+	TookMs float64 `json:"tookMs"`
+}
+
+func NewDTOV2(dto *DTO, took time.Duration) *DTOV2 {
+	facets := FacetsV2{
+		Price:          dto.PriceRanges,
+		FreeKilometers: dto.FreeKilometerRange,
+		PricePerDay:    dto.PricePerDayRanges,
+		StartDate:      dto.StartDateRanges,
+		SubRegion:      dto.SubRegionCounts,
+		Stats:          dto.Stats,
+	}
+	for carType, count := range dto.CarTypeCounts {
+		facets.CarType = append(facets.CarType, TermBucket[string]{Value: carType, Count: count})
+	}
+	slices.SortFunc(facets.CarType, func(a, b TermBucket[string]) int { return cmp.Compare(a.Value, b.Value) })
+	if dto.SeatsCount != nil {
+		// Seats are already sorted
+		numberSeats := make([]TermBucket[uint64], 0, len(*dto.SeatsCount))
+		for _, seats := range *dto.SeatsCount {
+			numberSeats = append(numberSeats, TermBucket[uint64]{Value: seats.NumberSeats, Count: seats.Count})
+		}
+		facets.NumberSeats = &numberSeats
+	}
+	if dto.VollkaskoCount != nil {
+		facets.Vollkasko = []TermBucket[bool]{
+			{Value: true, Count: dto.VollkaskoCount.TrueCount},
+			{Value: false, Count: dto.VollkaskoCount.FalseCount},
+		}
+	}
+
+	return &DTOV2{
+		Offers: dto.Offers,
+		Facets: facets,
+		Meta: MetaV2{
+			PageMeta: dto.Meta,
+			TookMs:   float64(took.Microseconds()) / 1000,
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	// Parquet is only supported for imports
+	FormatParquet = "parquet"
+)
+
+// OfferColumns are the CSV columns of an offer, in the JSON names of the offer attributes
+var OfferColumns = []string{"ID", "data", "mostSpecificRegionID", "startDate", "endDate", "numberSeats", "price", "carType", "hasVollkasko", "freeKilometers"}
+
+// OfferWriter encodes offers one by one, Close writes the end of the document.
+type OfferWriter interface {
+	Write(offer *Offer) error
+	Close() error
+}
+
+// NewOfferWriter creates a writer for FormatJSON, FormatNDJSON or FormatCSV. ok is false for unknown formats.
+func NewOfferWriter(w io.Writer, format string) (writer OfferWriter, contentType string, ok bool) {
+	switch format {
+	case FormatJSON, "":
+		return &jsonOfferWriter{w: bufio.NewWriter(w)}, "application/json", true
+	case FormatNDJSON:
+		return &ndjsonOfferWriter{w: bufio.NewWriter(w)}, "application/x-ndjson", true
+	case FormatCSV:
+		return &csvOfferWriter{w: csv.NewWriter(w)}, "text/csv", true
+	}
+	return nil, "", false
+}
+
+// jsonOfferWriter writes the offers in the body format of POST /api/offers
+type jsonOfferWriter struct {
+	w       *bufio.Writer
+	started bool
+}
+
+func (j *jsonOfferWriter) Write(offer *Offer) error {
+	prefix := ","
+	if !j.started {
+		prefix = `{"offers":[`
+		j.started = true
+	}
+	if _, err := j.w.WriteString(prefix); err != nil {
+		return err
+	}
+	b, err := json.Marshal(offer)
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(b)
+	return err
+}
+
+func (j *jsonOfferWriter) Close() error {
+	end := "]}"
+	if !j.started {
+		end = `{"offers":[]}`
+	}
+	if _, err := j.w.WriteString(end); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+type ndjsonOfferWriter struct {
+	w *bufio.Writer
+}
+
+func (n *ndjsonOfferWriter) Write(offer *Offer) error {
+	b, err := json.Marshal(offer)
+	if err != nil {
+		return err
+	}
+	if _, err := n.w.Write(b); err != nil {
+		return err
+	}
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonOfferWriter) Close() error {
+	return n.w.Flush()
+}
+
+type csvOfferWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func (c *csvOfferWriter) Write(offer *Offer) error {
+	if !c.started {
+		c.started = true
+		if err := c.w.Write(OfferColumns); err != nil {
+			return err
+		}
+	}
+	return c.w.Write([]string{
+		offer.ID.String(),
+		offer.Data,
+		strconv.FormatUint(offer.MostSpecificRegionID, 10),
+		strconv.FormatUint(offer.StartDate, 10),
+		strconv.FormatUint(offer.EndDate, 10),
+		strconv.FormatUint(offer.NumberSeats, 10),
+		strconv.FormatUint(offer.Price, 10),
+		offer.CarType,
+		strconv.FormatBool(offer.HasVollkasko),
+		strconv.FormatUint(offer.FreeKilometers, 10),
+	})
+}
+
+func (c *csvOfferWriter) Close() error {
+	if !c.started {
+		c.started = true
+		if err := c.w.Write(OfferColumns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
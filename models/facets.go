@@ -0,0 +1,82 @@
+package models
+
+// Predicate reports whether an offer passes a filter.
+type Predicate func(offer *Offer) bool
+
+// Aggregation collects the offers passed to it into a summary.
+type Aggregation interface {
+	Add(offer *Offer)
+}
+
+// AggregationFunc adapts a plain function to an Aggregation.
+type AggregationFunc func(offer *Offer)
+
+func (f AggregationFunc) Add(offer *Offer) { f(offer) }
+
+// Facet is an offer attribute with an optional filter and an optional aggregation.
+// The aggregation of a facet sees every offer that passes the filters of all other facets,
+// so the counts for values that are not selected are still shown (disjunctive faceting).
+type Facet struct {
+	Name   string
+	Filter Predicate
+	Agg    Aggregation
+}
+
+// FacetEngine evaluates the registered facets over a set of offers in a single pass.
+type FacetEngine struct {
+	facets []*Facet
+}
+
+func (e *FacetEngine) Register(facets ...*Facet) {
+	e.facets = append(e.facets, facets...)
+}
+
+// Run feeds the aggregations and returns the offers that pass all filters.
+func (e *FacetEngine) Run(offers []*Offer) []*Offer {
+	ret := make([]*Offer, 0, len(offers)/2)
+
+	for _, offer := range offers {
+		// An offer failing exactly one filter only counts for the facet of that filter
+		failed := -1
+		failures := 0
+		for i, facet := range e.facets {
+			if facet.Filter != nil && !facet.Filter(offer) {
+				failed = i
+				failures++
+				if failures > 1 {
+					break
+				}
+			}
+		}
+
+		switch failures {
+		case 0:
+			for _, facet := range e.facets {
+				if facet.Agg != nil {
+					facet.Agg.Add(offer)
+				}
+			}
+			ret = append(ret, offer)
+		case 1:
+			if agg := e.facets[failed].Agg; agg != nil {
+				agg.Add(offer)
+			}
+		}
+	}
+
+	return ret
+}
+
+// TermCount counts offers by a discrete attribute value.
+type TermCount[K comparable] struct {
+	Term   func(offer *Offer) K
+	Counts map[K]uint64
+}
+
+func NewTermCount[K comparable](term func(offer *Offer) K) *TermCount[K] {
+	return &TermCount[K]{Term: term, Counts: make(map[K]uint64)}
+}
+
+func (t *TermCount[K]) Add(offer *Offer) {
+	t.Counts[t.Term(offer)]++
+}
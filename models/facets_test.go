@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFacetEngineRun(t *testing.T) {
+	offers := []*Offer{
+		{CarType: "small", HasVollkasko: true, NumberSeats: 4},
+		{CarType: "small", HasVollkasko: false, NumberSeats: 4},
+		{CarType: "family", HasVollkasko: true, NumberSeats: 7},
+		{CarType: "family", HasVollkasko: false, NumberSeats: 7},
+		{CarType: "sports", HasVollkasko: true, NumberSeats: 2},
+	}
+	isSmall := func(o *Offer) bool { return o.CarType == "small" }
+	isVollkasko := func(o *Offer) bool { return o.HasVollkasko }
+
+	tests := []struct {
+		name      string
+		carType   Predicate
+		vollkasko Predicate
+		matches   int
+		carTypes  map[string]uint64
+		kasko     map[bool]uint64
+		seats     map[uint64]uint64
+	}{
+		{
+			name:     "no filters",
+			matches:  5,
+			carTypes: map[string]uint64{"small": 2, "family": 2, "sports": 1},
+			kasko:    map[bool]uint64{true: 3, false: 2},
+			seats:    map[uint64]uint64{4: 2, 7: 2, 2: 1},
+		},
+		{
+			name:     "own filter is ignored",
+			carType:  isSmall,
+			matches:  2,
+			carTypes: map[string]uint64{"small": 2, "family": 2, "sports": 1},
+			kasko:    map[bool]uint64{true: 1, false: 1},
+			seats:    map[uint64]uint64{4: 2},
+		},
+		{
+			name:      "all other filters apply",
+			carType:   isSmall,
+			vollkasko: isVollkasko,
+			matches:   1,
+			carTypes:  map[string]uint64{"small": 1, "family": 1, "sports": 1},
+			kasko:     map[bool]uint64{true: 1, false: 1},
+			seats:     map[uint64]uint64{4: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carTypes := NewTermCount(func(o *Offer) string { return o.CarType })
+			kasko := NewTermCount(func(o *Offer) bool { return o.HasVollkasko })
+			seats := NewTermCount(func(o *Offer) uint64 { return o.NumberSeats })
+
+			var engine FacetEngine
+			engine.Register(
+				&Facet{Name: "carType", Filter: tt.carType, Agg: carTypes},
+				&Facet{Name: "vollkasko", Filter: tt.vollkasko, Agg: kasko},
+				&Facet{Name: "numberSeats", Agg: seats},
+			)
+			if matches := engine.Run(offers); len(matches) != tt.matches {
+				t.Errorf("Run() returned %d offers, want %d", len(matches), tt.matches)
+			}
+			if !reflect.DeepEqual(carTypes.Counts, tt.carTypes) {
+				t.Errorf("car types = %v, want %v", carTypes.Counts, tt.carTypes)
+			}
+			if !reflect.DeepEqual(kasko.Counts, tt.kasko) {
+				t.Errorf("vollkasko = %v, want %v", kasko.Counts, tt.kasko)
+			}
+			if !reflect.DeepEqual(seats.Counts, tt.seats) {
+				t.Errorf("seats = %v, want %v", seats.Counts, tt.seats)
+			}
+		})
+	}
+}
+
+// TestFilterAggregationsDisjunctive compares the aggregations with counts over the offers
+// that pass all filters except the one of the aggregation, like FilterAggregations computed them
+// before the facet engine.
+func TestFilterAggregationsDisjunctive(t *testing.T) {
+	carTypes := []string{"small", "sports", "luxury", "family"}
+	offers := &Offers{}
+	for i := range 200 {
+		offers.Offers = append(offers.Offers, &Offer{
+			NumberSeats:    uint64(2 + i%6),
+			Price:          uint64(1000 + 397*i%20000),
+			CarType:        carTypes[i%len(carTypes)],
+			HasVollkasko:   i%3 == 0,
+			FreeKilometers: uint64(50 * (i % 7)),
+		})
+	}
+	ptr := func(v uint64) *uint64 { return &v }
+	yes := true
+
+	tests := []struct {
+		minSeats, minPrice, maxPrice, minFreeKilometer *uint64
+		carType                                        *string
+		onlyVollkasko                                  *bool
+	}{
+		{},
+		{minSeats: ptr(4)},
+		{minPrice: ptr(5000), maxPrice: ptr(15000), carType: &carTypes[1]},
+		{onlyVollkasko: &yes, minFreeKilometer: ptr(100)},
+		{minSeats: ptr(3), minPrice: ptr(2000), maxPrice: ptr(18000), minFreeKilometer: ptr(50), carType: &carTypes[3], onlyVollkasko: &yes},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			var carTypeList []string
+			if tt.carType != nil {
+				carTypeList = []string{*tt.carType}
+			}
+			priceOptions, freeKmOptions := HistogramOptions{Width: 1000}, HistogramOptions{Width: 100}
+			aggs, err := offers.FilterAggregations(
+				NewFilters(tt.minSeats, nil, tt.minPrice, tt.maxPrice, carTypeList, tt.onlyVollkasko, tt.minFreeKilometer, nil, nil),
+				AggregationOptions{PriceRanges: &priceOptions, FreeKilometerRanges: &freeKmOptions, CarTypeCounts: true, VollkaskoCount: true, SeatsCount: true},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedCarTypes := CarTypeCount{}
+			expectedKasko := VollkaskoCount{}
+			expectedSeats := map[uint64]uint64{}
+			expectedPrices := NewHistogram(OfferPrice, priceOptions)
+			expectedFreeKm := NewHistogram(OfferFreeKilometers, freeKmOptions)
+			matches := 0
+			for _, o := range offers.Offers {
+				seats := tt.minSeats == nil || o.NumberSeats >= *tt.minSeats
+				car := tt.carType == nil || o.CarType == *tt.carType
+				kasko := tt.onlyVollkasko == nil || !*tt.onlyVollkasko || o.HasVollkasko
+				freeKm := tt.minFreeKilometer == nil || o.FreeKilometers >= *tt.minFreeKilometer
+				price := (tt.minPrice == nil || o.Price >= *tt.minPrice) && (tt.maxPrice == nil || o.Price < *tt.maxPrice)
+
+				if seats && car && kasko && freeKm {
+					expectedPrices.Add(o)
+				}
+				if seats && car && kasko && price {
+					expectedFreeKm.Add(o)
+				}
+				if seats && freeKm && kasko && price {
+					expectedCarTypes[o.CarType]++
+				}
+				if seats && freeKm && car && price {
+					if o.HasVollkasko {
+						expectedKasko.TrueCount++
+					} else {
+						expectedKasko.FalseCount++
+					}
+				}
+				if kasko && freeKm && car && price {
+					expectedSeats[o.NumberSeats]++
+				}
+				if seats && kasko && freeKm && car && price {
+					matches++
+				}
+			}
+
+			if len(aggs.OptionalAgg.Offers) != matches {
+				t.Errorf("%d matching offers, want %d", len(aggs.OptionalAgg.Offers), matches)
+			}
+			// Known car types without offers are listed with a count of 0
+			carTypeCounts := CarTypeCount{}
+			for carType, count := range aggs.CarTypeCount {
+				if count > 0 {
+					carTypeCounts[carType] = count
+				}
+			}
+			if !reflect.DeepEqual(carTypeCounts, expectedCarTypes) {
+				t.Errorf("car types = %v, want %v", carTypeCounts, expectedCarTypes)
+			}
+			if *aggs.VollKaskoCount != expectedKasko {
+				t.Errorf("vollkasko = %v, want %v", *aggs.VollKaskoCount, expectedKasko)
+			}
+			seats := map[uint64]uint64{}
+			for n, s := range aggs.SeatsCount {
+				seats[n] = s.Count
+			}
+			if !reflect.DeepEqual(seats, expectedSeats) {
+				t.Errorf("seats = %v, want %v", seats, expectedSeats)
+			}
+			prices, _ := expectedPrices.Buckets()
+			if !reflect.DeepEqual(*aggs.PriceRanges, prices) {
+				t.Errorf("price ranges = %v, want %v", *aggs.PriceRanges, prices)
+			}
+			freeKm, _ := expectedFreeKm.Buckets()
+			if !reflect.DeepEqual(*aggs.FreeKilometerRange, freeKm) {
+				t.Errorf("free kilometer ranges = %v, want %v", *aggs.FreeKilometerRange, freeKm)
+			}
+		})
+	}
+}
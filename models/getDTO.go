@@ -1,15 +1,117 @@
 package models
 
+// OfferDTO is an offer in the search results. Only the selected attributes are set.
 type OfferDTO struct {
-	ID   string `json:"ID"`
-	Data string `json:"data"`
+	ID                   string  `json:"ID"`
+	Data                 *string `json:"data,omitempty"`
+	MostSpecificRegionID *uint64 `json:"mostSpecificRegionID,omitempty"`
+	StartDate            *uint64 `json:"startDate,omitempty"`
+	EndDate              *uint64 `json:"endDate,omitempty"`
+	NumberDays           *uint64 `json:"numberDays,omitempty"`
+	NumberSeats          *uint64 `json:"numberSeats,omitempty"`
+	Price                *uint64 `json:"price,omitempty"`
+	PricePerDay          *uint64 `json:"pricePerDay,omitempty"`
+	CarType              *string `json:"carType,omitempty"`
+	HasVollkasko         *bool   `json:"hasVollkasko,omitempty"`
+	FreeKilometers       *uint64 `json:"freeKilometers,omitempty"`
 }
 
+// OfferFields selects the attributes of an OfferDTO by their JSON names. The ID is always included,
+// nil selects the spec fields ID and data.
+type OfferFields map[string]bool
+
+// AllOfferFieldNames are the JSON names of every attribute of an offer
+var AllOfferFieldNames = []string{"data", "mostSpecificRegionID", "startDate", "endDate", "numberDays", "numberSeats", "price", "pricePerDay", "carType", "hasVollkasko", "freeKilometers"}
+
+// AllOfferFields selects every attribute of an offer
+var AllOfferFields = NewOfferFields(AllOfferFieldNames)
+
+func NewOfferFields(fields []string) OfferFields {
+	if len(fields) == 0 {
+		return nil
+	}
+	ret := make(OfferFields, len(fields))
+	for _, f := range fields {
+		ret[f] = true
+	}
+	return ret
+}
+
+func NewOfferDTO(offer *Offer, fields OfferFields) *OfferDTO {
+	dto := &OfferDTO{ID: offer.ID.String()}
+	if fields == nil {
+		dto.Data = &offer.Data
+		return dto
+	}
+
+	if fields["data"] {
+		dto.Data = &offer.Data
+	}
+	if fields["mostSpecificRegionID"] {
+		dto.MostSpecificRegionID = &offer.MostSpecificRegionID
+	}
+	if fields["startDate"] {
+		dto.StartDate = &offer.StartDate
+	}
+	if fields["endDate"] {
+		dto.EndDate = &offer.EndDate
+	}
+	if fields["numberDays"] {
+		dto.NumberDays = &offer.NumberDays
+	}
+	if fields["numberSeats"] {
+		dto.NumberSeats = &offer.NumberSeats
+	}
+	if fields["price"] {
+		dto.Price = &offer.Price
+	}
+	if fields["pricePerDay"] {
+		dto.PricePerDay = &offer.PricePerDay
+	}
+	if fields["carType"] {
+		dto.CarType = &offer.CarType
+	}
+	if fields["hasVollkasko"] {
+		dto.HasVollkasko = &offer.HasVollkasko
+	}
+	if fields["freeKilometers"] {
+		dto.FreeKilometers = &offer.FreeKilometers
+	}
+	return dto
+}
+
+// DTO is the search response. Aggregations that are not selected are nil and left out,
+// selected ones without matches are empty.
 type DTO struct {
-	Offers             []*OfferDTO      `json:"offers"`
-	PriceRanges        []HistogramRange `json:"priceRanges"`
-	CarTypeCounts      CarTypeCount     `json:"carTypeCounts"`
-	SeatsCount         []*KVSeatsCount  `json:"seatsCount"`
-	FreeKilometerRange []HistogramRange `json:"freeKilometerRange"`
-	VollkaskoCount     VollkaskoCount   `json:"vollkaskoCount"`
+	Offers             []*OfferDTO         `json:"offers"`
+	PriceRanges        *[]HistogramRange   `json:"priceRanges,omitempty"`
+	CarTypeCounts      CarTypeCount        `json:"carTypeCounts,omitempty"`
+	SeatsCount         *[]*KVSeatsCount    `json:"seatsCount,omitempty"`
+	FreeKilometerRange *[]HistogramRange   `json:"freeKilometerRange,omitempty"`
+	VollkaskoCount     *VollkaskoCount     `json:"vollkaskoCount,omitempty"`
+	PricePerDayRanges  *[]HistogramRange   `json:"pricePerDayRanges,omitempty"`
+	StartDateRanges    *[]HistogramRange   `json:"startDateRanges,omitempty"`
+	SubRegionCounts    []*KVSubRegionCount `json:"subRegionCounts,omitempty"`
+	Stats              *StatsSummary       `json:"stats,omitempty"`
+	Meta               PageMeta            `json:"meta"`
+}
+
+// PageMeta describes the page of a search result
+type PageMeta struct {
+	// Number of offers matching all filters
+	Total    uint64 `json:"total"`
+	Page     uint64 `json:"page"`
+	PageSize uint64 `json:"pageSize"`
+	HasNext  bool   `json:"hasNext"`
+}
+
+// LookupRequest is the body of POST /api/offers/lookup
+type LookupRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// LookupDTO holds the details of the found offers and the IDs that are not known
+type LookupDTO struct {
+	Offers   []*OfferDTO `json:"offers"`
+	NotFound []string    `json:"notFound"`
 }
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// MaxEmptyBuckets limits the buckets of a histogram that includes the empty buckets
+const MaxEmptyBuckets = 10000
+
+var ErrTooManyBuckets = errors.New("histogram with more than 10000 buckets, increase the width")
+
+// HistogramOptions configures the buckets of a histogram. The first set of
+// Boundaries, LogBase, Auto and Width decides how values are bucketized.
+type HistogramOptions struct {
+	// Ascending bucket boundaries, values outside of the first and last boundary are not counted
+	Boundaries []uint64 `json:"boundaries,omitempty"`
+	// Base of logarithmic buckets [0, 1), [1, base), [base, base^2), ... if at least 2
+	LogBase uint64 `json:"logBase,omitempty"`
+	// Target number of fixed width buckets, the width is chosen from the range of the data
+	Auto uint64 `json:"auto,omitempty"`
+	// Width of fixed width buckets. Bucket starts and ends are a multiple of the width.
+	Width uint64 `json:"width,omitempty"`
+	// Include the empty buckets between the lowest and the highest bucket
+	Empty bool `json:"empty,omitempty"`
+}
+
+// Enabled reports whether the options describe any buckets at all.
+func (o HistogramOptions) Enabled() bool {
+	return len(o.Boundaries) >= 2 || o.LogBase >= 2 || o.Auto > 0 || o.Width > 0
+}
+
+// Histogram counts offers in buckets of a value.
+type Histogram struct {
+	Value   func(offer *Offer) uint64
+	Options HistogramOptions
+
+	counts map[uint64]*HistogramRange
+	// raw value counts, only used for auto bucketing as the range is only known at the end
+	values map[uint64]uint64
+}
+
+func NewHistogram(value func(offer *Offer) uint64, options HistogramOptions) *Histogram {
+	h := &Histogram{Value: value, Options: options, counts: make(map[uint64]*HistogramRange)}
+	if len(options.Boundaries) < 2 && options.LogBase < 2 && options.Auto > 0 {
+		h.values = make(map[uint64]uint64)
+	}
+	return h
+}
+
+func (h *Histogram) Add(offer *Offer) {
+	v := h.Value(offer)
+	if h.values != nil {
+		h.values[v]++
+		return
+	}
+	h.add(v, 1)
+}
+
+func (h *Histogram) add(v uint64, count uint64) {
+	start, end, ok := h.bucket(v)
+	if !ok {
+		return
+	}
+	if b, exists := h.counts[start]; exists {
+		b.Count += count
+	} else {
+		h.counts[start] = &HistogramRange{Start: start, End: end, Count: count}
+	}
+}
+
+// bucket returns the bucket containing v
+func (h *Histogram) bucket(v uint64) (start uint64, end uint64, ok bool) {
+	o := h.Options
+	switch {
+	case len(o.Boundaries) >= 2:
+		i := sort.Search(len(o.Boundaries), func(i int) bool { return o.Boundaries[i] > v }) - 1
+		if i < 0 || i >= len(o.Boundaries)-1 {
+			return 0, 0, false
+		}
+		return o.Boundaries[i], o.Boundaries[i+1], true
+	case o.LogBase >= 2:
+		if v == 0 {
+			return 0, 1, true
+		}
+		start = 1
+		for start <= v/o.LogBase {
+			start *= o.LogBase
+		}
+		if start > math.MaxUint64/o.LogBase {
+			return start, math.MaxUint64, true
+		}
+		return start, start * o.LogBase, true
+	case o.Width > 0:
+		start = v / o.Width * o.Width
+		return start, start + o.Width, true
+	}
+	return 0, 0, false
+}
+
+// Buckets returns the buckets sorted by start ascending. Empty buckets are only included if requested,
+// at most MaxEmptyBuckets of them.
+func (h *Histogram) Buckets() ([]HistogramRange, error) {
+	if h.values != nil {
+		h.bucketizeAuto()
+	}
+
+	buckets := make([]HistogramRange, 0, len(h.counts))
+	for _, b := range h.counts {
+		buckets = append(buckets, *b)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start < buckets[j].Start
+	})
+
+	if !h.Options.Empty || len(buckets) < 2 {
+		return buckets, nil
+	}
+
+	// Walk from the lowest to the highest bucket, the end of a bucket is the start of the next one
+	last := buckets[len(buckets)-1].Start
+	filled := make([]HistogramRange, 0, len(buckets))
+	start, end, _ := h.bucket(buckets[0].Start)
+	for {
+		if len(filled) == MaxEmptyBuckets {
+			return nil, ErrTooManyBuckets
+		}
+		if b, ok := h.counts[start]; ok {
+			filled = append(filled, *b)
+		} else {
+			filled = append(filled, HistogramRange{Start: start, End: end})
+		}
+		if start >= last || end == math.MaxUint64 {
+			break
+		}
+		start, end, _ = h.bucket(end)
+	}
+	return filled, nil
+}
+
+// bucketizeAuto picks a width that yields about the requested number of buckets and counts the collected values
+func (h *Histogram) bucketizeAuto() {
+	if len(h.values) > 0 {
+		lo, hi := uint64(math.MaxUint64), uint64(0)
+		for v := range h.values {
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+		h.Options.Width = niceWidth((hi - lo + h.Options.Auto) / h.Options.Auto)
+		for v, count := range h.values {
+			h.add(v, count)
+		}
+	}
+	h.values = nil
+}
+
+// niceWidth rounds a width up to 1, 2 or 5 times a power of ten
+func niceWidth(raw uint64) uint64 {
+	if raw <= 1 {
+		return 1
+	}
+	for p := uint64(1); ; p *= 10 {
+		for _, m := range []uint64{1, 2, 5} {
+			if m*p >= raw {
+				return m * p
+			}
+		}
+		if p > math.MaxUint64/100 {
+			return raw
+		}
+	}
+}
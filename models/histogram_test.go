@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistogramEmptyBuckets(t *testing.T) {
+	h := NewHistogram(func(o *Offer) uint64 { return o.Price }, HistogramOptions{Width: 10, Empty: true})
+	for _, price := range []uint64{5, 7, 31} {
+		h.Add(&Offer{Price: price})
+	}
+
+	buckets, err := h.Buckets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []HistogramRange{{0, 10, 2}, {10, 20, 0}, {20, 30, 0}, {30, 40, 1}}
+	if !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("Buckets() = %v, want %v", buckets, expected)
+	}
+}
+
+func TestHistogramTooManyEmptyBuckets(t *testing.T) {
+	h := NewHistogram(func(o *Offer) uint64 { return o.Price }, HistogramOptions{Width: 1, Empty: true})
+	h.Add(&Offer{Price: 1})
+	h.Add(&Offer{Price: 10_000_000_000})
+
+	if _, err := h.Buckets(); !errors.Is(err, ErrTooManyBuckets) {
+		t.Errorf("Buckets() error = %v, want %v", err, ErrTooManyBuckets)
+	}
+
+	// Without the empty buckets only the two filled ones are returned
+	h = NewHistogram(func(o *Offer) uint64 { return o.Price }, HistogramOptions{Width: 1})
+	h.Add(&Offer{Price: 1})
+	h.Add(&Offer{Price: 10_000_000_000})
+	if buckets, err := h.Buckets(); err != nil || len(buckets) != 2 {
+		t.Errorf("Buckets() = %v, %v, want 2 buckets", buckets, err)
+	}
+}
+
+func TestDateHistogramBeforeEpoch(t *testing.T) {
+	h := NewDateHistogram(func(o *Offer) uint64 { return o.StartDate }, DateHistogramOptions{Interval: IntervalDay, Location: time.FixedZone("UTC+2", 2*60*60)})
+	h.Add(&Offer{StartDate: 0})
+	h.Add(&Offer{StartDate: 60 * 60 * 1000})
+
+	expected := []HistogramRange{{0, 22 * 60 * 60 * 1000, 2}}
+	if buckets := h.Buckets(); !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("Buckets() = %v, want %v", buckets, expected)
+	}
+}
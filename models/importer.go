@@ -0,0 +1,200 @@
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+// ColumnMapping maps the JSON names of the offer attributes to the column names of an imported file.
+// Attributes that are not mapped are read from the column with the same name.
+type ColumnMapping map[string]string
+
+func (m ColumnMapping) column(attribute string) string {
+	if column, ok := m[attribute]; ok {
+		return column
+	}
+	return attribute
+}
+
+// indices returns the index of every offer attribute in the header, all attributes are required
+func (m ColumnMapping) indices(header []string) ([]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.TrimSpace(name)] = i
+	}
+
+	indices := make([]int, len(OfferColumns))
+	for i, attribute := range OfferColumns {
+		pos, ok := positions[m.column(attribute)]
+		if !ok {
+			return nil, fmt.Errorf("missing column %q for %s", m.column(attribute), attribute)
+		}
+		indices[i] = pos
+	}
+	return indices, nil
+}
+
+// IngestCSV imports offers from a CSV file with a header row.
+func (i *Ingester) IngestCSV(r io.Reader, mapping ColumnMapping) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+	indices, err := mapping.indices(header)
+	if err != nil {
+		return err
+	}
+
+	values := make([]string, len(indices))
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return i.Flush()
+		}
+
+		// Malformed lines are reported, errors of the underlying reader end the import
+		var line int
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err != nil {
+			return err
+		} else {
+			line, _ = reader.FieldPos(0)
+		}
+
+		var offer *Offer
+		if err == nil {
+			for j, index := range indices {
+				if index >= len(record) {
+					err = fmt.Errorf("missing value for %s", OfferColumns[j])
+					break
+				}
+				values[j] = record[index]
+			}
+		}
+		if err == nil {
+			offer, err = parseOfferRecord(values)
+		}
+		if insertErr := i.Add(uint64(line), offer, err); insertErr != nil {
+			return insertErr
+		}
+	}
+}
+
+// IngestParquet imports offers from a Parquet file with flat columns.
+func (i *Ingester) IngestParquet(r io.ReaderAt, size int64, mapping ColumnMapping) error {
+	file, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return err
+	}
+
+	columns := file.Schema().Columns()
+	header := make([]string, len(columns))
+	for j, path := range columns {
+		header[j] = strings.Join(path, ".")
+	}
+	indices, err := mapping.indices(header)
+	if err != nil {
+		return err
+	}
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	rows := make([]parquet.Row, 256)
+	values := make([]string, len(indices))
+	byColumn := make([]parquet.Value, len(columns))
+	rowNumber := uint64(0)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			rowNumber++
+
+			clear(byColumn)
+			for _, v := range row {
+				byColumn[v.Column()] = v
+			}
+			for j, index := range indices {
+				values[j] = parquetString(byColumn[index])
+			}
+
+			offer, parseErr := parseOfferRecord(values)
+			if insertErr := i.Add(rowNumber, offer, parseErr); insertErr != nil {
+				return insertErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return i.Flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func parquetString(v parquet.Value) string {
+	if v.IsNull() {
+		return ""
+	}
+	// UUIDs are stored as 16 raw bytes
+	if v.Kind() == parquet.FixedLenByteArray && len(v.ByteArray()) == 16 {
+		if id, err := uuid.FromBytes(v.ByteArray()); err == nil {
+			return id.String()
+		}
+	}
+	if v.Kind() == parquet.Double {
+		return strconv.FormatFloat(v.Double(), 'f', -1, 64)
+	}
+	return v.String()
+}
+
+// parseOfferRecord parses the values of an offer in the order of OfferColumns
+func parseOfferRecord(values []string) (*Offer, error) {
+	offer := &Offer{}
+	var err error
+
+	if offer.ID, err = uuid.Parse(values[0]); err != nil {
+		return nil, fmt.Errorf("ID: %w", err)
+	}
+	offer.Data = values[1]
+	numbers := []*uint64{&offer.MostSpecificRegionID, &offer.StartDate, &offer.EndDate, &offer.NumberSeats, &offer.Price}
+	for j, target := range numbers {
+		if *target, err = parseUint(values[2+j]); err != nil {
+			return nil, fmt.Errorf("%s: %w", OfferColumns[2+j], err)
+		}
+	}
+	offer.CarType = values[7]
+	if offer.HasVollkasko, err = strconv.ParseBool(values[8]); err != nil {
+		return nil, fmt.Errorf("hasVollkasko: %w", err)
+	}
+	if offer.FreeKilometers, err = parseUint(values[9]); err != nil {
+		return nil, fmt.Errorf("freeKilometers: %w", err)
+	}
+	return offer, nil
+}
+
+// parseUint also accepts integral floats, as spreadsheets tend to export numbers as 1.0
+func parseUint(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f != float64(uint64(f)) {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return uint64(f), nil
+}
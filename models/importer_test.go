@@ -0,0 +1,30 @@
+package models
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestIngestCSVReturnsReadErrors(t *testing.T) {
+	readErr := errors.New("connection reset")
+	input := io.MultiReader(
+		strings.NewReader(strings.Join(OfferColumns, ",")+"\n"+`a"b,1,2,3,4,5,6,7,8,9`+"\n"),
+		failingReader{readErr},
+	)
+	ingester := NewIngester(10, func([]*Offer) error { return nil })
+
+	err := ingester.IngestCSV(input, nil)
+	if !errors.Is(err, readErr) {
+		t.Fatalf("IngestCSV() = %v, want %v", err, readErr)
+	}
+	// The malformed line is reported, the read error is not a line
+	if ingester.Report.Lines != 1 || ingester.Report.Failed != 1 {
+		t.Errorf("report = %+v, want 1 failed line", ingester.Report)
+	}
+}
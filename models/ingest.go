@@ -0,0 +1,111 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+)
+
+// At most this many line errors are reported, the rest is only counted
+const maxReportedErrors = 100
+
+// LineError is an offer that could not be ingested
+type LineError struct {
+	Line  uint64 `json:"line"`
+	Error string `json:"error"`
+}
+
+// IngestReport summarizes an ingestion
+type IngestReport struct {
+	Lines   uint64      `json:"lines"`
+	Created uint64      `json:"created"`
+	Failed  uint64      `json:"failed"`
+	Errors  []LineError `json:"errors"`
+}
+
+// Ingester validates offers and inserts them in batches.
+// Progress is called with the report after every full batch if set, the final report is left to the caller.
+type Ingester struct {
+	BatchSize int
+	Insert    func(offers []*Offer) error
+	Progress  func(report IngestReport)
+	Report    IngestReport
+
+	batch []*Offer
+}
+
+func NewIngester(batchSize int, insert func(offers []*Offer) error) *Ingester {
+	return &Ingester{
+		BatchSize: batchSize,
+		Insert:    insert,
+		Report:    IngestReport{Errors: []LineError{}},
+		batch:     make([]*Offer, 0, batchSize),
+	}
+}
+
+// Add ingests the offer decoded from a line, or records the decoding error.
+// Only errors of the insertion are returned, they abort the ingestion.
+func (i *Ingester) Add(line uint64, offer *Offer, err error) error {
+	i.Report.Lines++
+	if err == nil {
+		err = offer.Validate()
+	}
+	if err != nil {
+		i.Report.Failed++
+		if len(i.Report.Errors) < maxReportedErrors {
+			i.Report.Errors = append(i.Report.Errors, LineError{Line: line, Error: err.Error()})
+		}
+		return nil
+	}
+
+	i.batch = append(i.batch, offer)
+	if len(i.batch) >= i.BatchSize {
+		if err := i.Flush(); err != nil {
+			return err
+		}
+		if i.Progress != nil {
+			i.Progress(i.Report)
+		}
+	}
+	return nil
+}
+
+// Flush inserts the pending offers
+func (i *Ingester) Flush() error {
+	if len(i.batch) == 0 {
+		return nil
+	}
+	if err := i.Insert(i.batch); err != nil {
+		return err
+	}
+	i.Report.Created += uint64(len(i.batch))
+	slog.Info("Ingested offers", "lines", i.Report.Lines, "created", i.Report.Created, "failed", i.Report.Failed)
+
+	i.batch = make([]*Offer, 0, i.BatchSize)
+	return nil
+}
+
+// IngestNDJSON decodes one offer per line, empty lines are skipped.
+func (i *Ingester) IngestNDJSON(r io.Reader) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for line := uint64(1); ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			var offer Offer
+			decodeErr := json.Unmarshal(b, &offer)
+			if insertErr := i.Add(line, &offer, decodeErr); insertErr != nil {
+				return insertErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return i.Flush()
+		}
+	}
+}
@@ -10,24 +10,6 @@ type Offers struct {
 	Offers []*Offer `json:"offers"`
 }
 
-type ByPrice struct {
-	Offers []*Offer
-	Asc    bool
-}
-
-func (a ByPrice) Len() int      { return len(a.Offers) }
-func (a ByPrice) Swap(i, j int) { a.Offers[i], a.Offers[j] = a.Offers[j], a.Offers[i] }
-func (a ByPrice) Less(i, j int) bool {
-	if a.Offers[i].Price == a.Offers[j].Price {
-		return a.Offers[i].ID.String() < a.Offers[j].ID.String()
-	}
-
-	if !a.Asc {
-		return a.Offers[i].Price > a.Offers[j].Price
-	}
-	return a.Offers[i].Price < a.Offers[j].Price
-}
-
 // Offer represents the offer details.
 type Offer struct {
 	ID                   uuid.UUID `json:"ID"`
@@ -38,11 +20,20 @@ type Offer struct {
 	NumberDays           uint64    `json:"-"`
 	NumberSeats          uint64    `json:"numberSeats"`
 	Price                uint64    `json:"price"`
+	PricePerDay          uint64    `json:"-"`
 	CarType              string    `json:"carType"`
 	HasVollkasko         bool      `json:"hasVollkasko"`
 	FreeKilometers       uint64    `json:"freeKilometers"`
 }
 
+// Derive computes the attributes that are not part of the posted offer.
+func (offer *Offer) Derive() {
+	offer.NumberDays = (offer.EndDate - offer.StartDate) / MsFactor
+	// Offers shorter than a day are priced as one day
+	days := max(offer.NumberDays, 1)
+	offer.PricePerDay = (offer.Price + days/2) / days
+}
+
 func (offers *Offers) FilterMandatory(start uint64, end uint64, num uint64) (ret *Offers) {
 	tmp_offers := make([]*Offer, 0, len(offers.Offers)/2)
 	for _, offer := range offers.Offers {
@@ -55,88 +46,132 @@ func (offers *Offers) FilterMandatory(start uint64, end uint64, num uint64) (ret
 	return &Offers{Offers: tmp_offers}
 }
 
+// Aggregations holds the computed aggregations, the unselected ones are nil.
 type Aggregations struct {
-	PricesAgg      *Offers
-	FreeKmAgg      *Offers
-	CarTypeCount   CarTypeCount
-	VollKaskoCount VollkaskoCount
-	SeatsCount     SeatsSummary
-	OptionalAgg    *Offers
+	PriceRanges        *[]HistogramRange
+	PricePerDayRanges  *[]HistogramRange
+	FreeKilometerRange *[]HistogramRange
+	StartDateRanges    *[]HistogramRange
+	CarTypeCount       CarTypeCount
+	VollKaskoCount     *VollkaskoCount
+	SeatsCount         SeatsSummary
+	SubRegionCount     *SubRegionSummary
+	Stats              *StatsSummary
+	OptionalAgg        *Offers
 }
 
-func (offers *Offers) FilterAggregations(numSeats *uint64, minPrice *uint64, maxPrice *uint64, carType *string, onlyVollkasko *bool, minFreeKilometer *uint64) (ret *Aggregations) {
-	ret = &Aggregations{
-		PricesAgg: &Offers{
-			Offers: make([]*Offer, 0, len(offers.Offers)/2),
-		},
-		FreeKmAgg: &Offers{
-			Offers: make([]*Offer, 0, len(offers.Offers)/2),
-		},
-		CarTypeCount:   CarTypeCount{},
-		VollKaskoCount: VollkaskoCount{},
-		SeatsCount:     SeatsSummary{},
-		OptionalAgg: &Offers{
-			Offers: make([]*Offer, 0, len(offers.Offers)/2),
-		},
+// FilterAggregations applies the optional filters and computes every selected facet with all filters except its own.
+func (offers *Offers) FilterAggregations(filters Filters, options AggregationOptions) (ret *Aggregations, err error) {
+	ret = &Aggregations{}
+	engine := &FacetEngine{}
+
+	// Filters that do not belong to a facet
+	engine.Register(&Facet{Name: "where", Filter: filters.Where})
+
+	var prices, freeKm, pricePerDay *Histogram
+	priceFacet := &Facet{Name: "price", Filter: filters.Price}
+	if options.PriceRanges != nil {
+		ret.PriceRanges = &[]HistogramRange{}
+		if options.PriceRanges.Enabled() {
+			prices = NewHistogram(func(o *Offer) uint64 { return o.Price }, *options.PriceRanges)
+			priceFacet.Agg = prices
+		}
 	}
-
-	for _, offer := range offers.Offers {
-		var boolSeats = numSeats == nil || offer.NumberSeats >= *numSeats
-		var boolCar = carType == nil || offer.CarType == *carType
-		var boolKasko = onlyVollkasko == nil || *onlyVollkasko == false || offer.HasVollkasko == *onlyVollkasko
-		var boolFreeKm = minFreeKilometer == nil || offer.FreeKilometers >= *minFreeKilometer
-		var boolPrice = ((minPrice == nil && maxPrice == nil) || (minPrice == nil || offer.Price >= *minPrice) && (maxPrice == nil || offer.Price < *maxPrice))
-
-		// For prices aggregation
-		if boolSeats &&
-			boolCar &&
-			boolKasko &&
-			boolFreeKm {
-			ret.PricesAgg.Offers = append(ret.PricesAgg.Offers, offer)
+	freeKmFacet := &Facet{Name: "freeKilometers", Filter: filters.FreeKilometers}
+	if options.FreeKilometerRanges != nil {
+		ret.FreeKilometerRange = &[]HistogramRange{}
+		if options.FreeKilometerRanges.Enabled() {
+			freeKm = NewHistogram(func(o *Offer) uint64 { return o.FreeKilometers }, *options.FreeKilometerRanges)
+			freeKmFacet.Agg = freeKm
 		}
+	}
+	pricePerDayFacet := &Facet{Name: "pricePerDay", Filter: filters.PricePerDay}
+	if options.PricePerDayRanges.Enabled() {
+		pricePerDay = NewHistogram(func(o *Offer) uint64 { return o.PricePerDay }, options.PricePerDayRanges)
+		pricePerDayFacet.Agg = pricePerDay
+	}
 
-		// For free km aggregation
-		if boolSeats &&
-			boolCar &&
-			boolKasko &&
-			boolPrice {
-			ret.FreeKmAgg.Offers = append(ret.FreeKmAgg.Offers, offer)
-		}
+	var carTypes *TermCount[string]
+	carTypeFacet := &Facet{Name: "carType", Filter: filters.CarType}
+	if options.CarTypeCounts {
+		carTypes = NewTermCount(func(o *Offer) string { return o.CarType })
+		carTypeFacet.Agg = carTypes
+	}
+	var vollkasko *TermCount[bool]
+	vollkaskoFacet := &Facet{Name: "vollkasko", Filter: filters.Vollkasko}
+	if options.VollkaskoCount {
+		vollkasko = NewTermCount(func(o *Offer) bool { return o.HasVollkasko })
+		vollkaskoFacet.Agg = vollkasko
+	}
+	var seats *TermCount[uint64]
+	seatsFacet := &Facet{Name: "numberSeats", Filter: filters.NumberSeats}
+	if options.SeatsCount {
+		seats = NewTermCount(func(o *Offer) uint64 { return o.NumberSeats })
+		seatsFacet.Agg = seats
+	}
 
-		// For car type aggregation
-		if boolSeats &&
-			boolFreeKm &&
-			boolKasko &&
-			boolPrice {
-			ret.CarTypeCount.Add(offer.CarType)
-		}
+	engine.Register(priceFacet, freeKmFacet, carTypeFacet, vollkaskoFacet, seatsFacet, pricePerDayFacet)
 
-		// For vollkasko aggregation
-		if boolSeats &&
-			boolFreeKm &&
-			boolCar &&
-			boolPrice {
-			ret.VollKaskoCount.Add(offer.HasVollkasko)
-		}
+	var startDates *DateHistogram
+	if options.StartDateRanges.Enabled() {
+		startDates = NewDateHistogram(func(o *Offer) uint64 { return o.StartDate }, options.StartDateRanges)
+		engine.Register(&Facet{Name: "startDate", Agg: startDates})
+	}
 
-		// For seats aggregation
-		if boolKasko &&
-			boolFreeKm &&
-			boolCar &&
-			boolPrice {
-			ret.SeatsCount.Add(offer.NumberSeats)
-		}
+	if options.BreakdownRegion != nil {
+		ret.SubRegionCount = NewSubRegionSummary(*options.BreakdownRegion)
+		engine.Register(&Facet{Name: "subRegion", Agg: AggregationFunc(func(o *Offer) {
+			ret.SubRegionCount.Add(int32(o.MostSpecificRegionID))
+		})})
+	}
 
-		// For optional aggregation
-		if boolSeats &&
-			boolKasko &&
-			boolFreeKm &&
-			boolCar &&
-			boolPrice {
-			ret.OptionalAgg.Offers = append(ret.OptionalAgg.Offers, offer)
-		}
+	var priceStats, freeKmStats *Summary
+	if options.Stats {
+		priceStats = NewSummary(func(o *Offer) uint64 { return o.Price })
+		freeKmStats = NewSummary(func(o *Offer) uint64 { return o.FreeKilometers })
+		engine.Register(
+			&Facet{Name: "priceStats", Agg: priceStats},
+			&Facet{Name: "freeKilometersStats", Agg: freeKmStats},
+		)
+	}
+
+	ret.OptionalAgg = &Offers{Offers: engine.Run(offers.Offers)}
 
+	if options.Stats {
+		ret.Stats = &StatsSummary{Price: priceStats.Stats(), FreeKilometers: freeKmStats.Stats()}
+	}
+	if prices != nil {
+		if *ret.PriceRanges, err = prices.Buckets(); err != nil {
+			return nil, err
+		}
+	}
+	if freeKm != nil {
+		if *ret.FreeKilometerRange, err = freeKm.Buckets(); err != nil {
+			return nil, err
+		}
+	}
+	if pricePerDay != nil {
+		ret.PricePerDayRanges = &[]HistogramRange{}
+		if *ret.PricePerDayRanges, err = pricePerDay.Buckets(); err != nil {
+			return nil, err
+		}
+	}
+	if startDates != nil {
+		startDateRanges := startDates.Buckets()
+		ret.StartDateRanges = &startDateRanges
+	}
+	if carTypes != nil {
+		ret.CarTypeCount = NewCarTypeCount(carTypes.Counts)
+	}
+	if vollkasko != nil {
+		ret.VollKaskoCount = &VollkaskoCount{TrueCount: vollkasko.Counts[true], FalseCount: vollkasko.Counts[false]}
+	}
+	if seats != nil {
+		ret.SeatsCount = make(SeatsSummary, len(seats.Counts))
+		for numberSeats, count := range seats.Counts {
+			ret.SeatsCount[numberSeats] = &KVSeatsCount{NumberSeats: numberSeats, Count: count}
+		}
 	}
 
-	return ret
+	return ret, nil
 }
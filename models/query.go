@@ -0,0 +1,197 @@
+package models
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// SearchQuery is a compiled search. The GET parameters and the JSON search documents both compile to it.
+type SearchQuery struct {
+	// Offers of all regions are searched, offers in overlapping regions are only returned once
+	RegionIDs      []int32
+	TimeRangeStart uint64
+	TimeRangeEnd   uint64
+	NumberDays     uint64
+	Filters        Filters
+	Aggregations   AggregationOptions
+	Sort           []SortKey
+	Page           uint64
+	PageSize       uint64
+	Fields         OfferFields
+}
+
+// Filters holds the filter of every facet, nil if the facet is not filtered.
+type Filters struct {
+	Price          Predicate
+	PricePerDay    Predicate
+	FreeKilometers Predicate
+	CarType        Predicate
+	Vollkasko      Predicate
+	NumberSeats    Predicate
+	// Where filters offers without being attributed to a facet
+	Where Predicate
+}
+
+// AggregationOptions selects the aggregations to compute.
+type AggregationOptions struct {
+	// The histograms of the spec, nil if not selected. Disabled options select a histogram without buckets.
+	PriceRanges         *HistogramOptions
+	FreeKilometerRanges *HistogramOptions
+	PricePerDayRanges   HistogramOptions
+	StartDateRanges     DateHistogramOptions
+	CarTypeCounts       bool
+	VollkaskoCount      bool
+	SeatsCount          bool
+	// Count the matching offers per direct subregion of this region if not nil
+	BreakdownRegion *int32
+	Stats           bool
+}
+
+// Select restricts the aggregations to the named ones, the names are the keys in the response.
+// Unselected aggregations are not computed and left out of the response.
+func (o AggregationOptions) Select(facets []string) AggregationOptions {
+	selected := toSet(facets)
+	has := func(name string) bool { _, ok := selected[name]; return ok }
+
+	if !has("priceRanges") {
+		o.PriceRanges = nil
+	}
+	if !has("freeKilometerRange") {
+		o.FreeKilometerRanges = nil
+	}
+	if !has("pricePerDayRanges") {
+		o.PricePerDayRanges = HistogramOptions{}
+	}
+	if !has("startDateRanges") {
+		o.StartDateRanges = DateHistogramOptions{}
+	}
+	if !has("subRegionCounts") {
+		o.BreakdownRegion = nil
+	}
+	o.CarTypeCounts = has("carTypeCounts")
+	o.VollkaskoCount = has("vollkaskoCount")
+	o.SeatsCount = has("seatsCount")
+	o.Stats = has("stats")
+	return o
+}
+
+// NewFilters creates the filters of the GET query parameters, nil parameters are not filtered.
+// An offer matches carTypes and numberSeats if it has any of the values.
+func NewFilters(numSeats *uint64, numberSeats []uint64, minPrice *uint64, maxPrice *uint64, carTypes []string, onlyVollkasko *bool, minFreeKilometer *uint64, minPricePerDay *uint64, maxPricePerDay *uint64) Filters {
+	return Filters{
+		Price:          rangeFilter(OfferPrice, minPrice, maxPrice),
+		PricePerDay:    rangeFilter(OfferPricePerDay, minPricePerDay, maxPricePerDay),
+		FreeKilometers: rangeFilter(OfferFreeKilometers, minFreeKilometer, nil),
+		CarType:        carTypeFilter(carTypes),
+		Vollkasko:      vollkaskoFilter(onlyVollkasko),
+		NumberSeats:    and(rangeFilter(OfferNumberSeats, numSeats, nil), setFilter(OfferNumberSeats, numberSeats)),
+	}
+}
+
+// rangeFilter filters a value by an inclusive minimum and an exclusive maximum
+func rangeFilter(value func(o *Offer) uint64, minValue *uint64, maxValue *uint64) Predicate {
+	if minValue == nil && maxValue == nil {
+		return nil
+	}
+	return func(o *Offer) bool {
+		v := value(o)
+		return (minValue == nil || v >= *minValue) && (maxValue == nil || v < *maxValue)
+	}
+}
+
+// setFilter filters a value by a set of allowed values
+func setFilter(value func(o *Offer) uint64, values []uint64) Predicate {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[uint64]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return func(o *Offer) bool {
+		_, ok := set[value(o)]
+		return ok
+	}
+}
+
+func carTypeFilter(carTypes []string) Predicate {
+	if len(carTypes) == 0 {
+		return nil
+	}
+	if len(carTypes) == 1 {
+		carType := carTypes[0]
+		return func(o *Offer) bool { return o.CarType == carType }
+	}
+	set := toSet(carTypes)
+	return func(o *Offer) bool {
+		_, ok := set[o.CarType]
+		return ok
+	}
+}
+
+func vollkaskoFilter(onlyVollkasko *bool) Predicate {
+	if onlyVollkasko == nil || !*onlyVollkasko {
+		return nil
+	}
+	return func(o *Offer) bool { return o.HasVollkasko }
+}
+
+func OfferPrice(o *Offer) uint64          { return o.Price }
+func OfferPricePerDay(o *Offer) uint64    { return o.PricePerDay }
+func OfferFreeKilometers(o *Offer) uint64 { return o.FreeKilometers }
+func OfferNumberSeats(o *Offer) uint64    { return o.NumberSeats }
+
+// NumericAttributes are the numeric offer attributes that can be filtered and sorted on by name.
+var NumericAttributes = map[string]func(o *Offer) uint64{
+	"price":                OfferPrice,
+	"pricePerDay":          OfferPricePerDay,
+	"freeKilometers":       OfferFreeKilometers,
+	"numberSeats":          OfferNumberSeats,
+	"startDate":            func(o *Offer) uint64 { return o.StartDate },
+	"endDate":              func(o *Offer) uint64 { return o.EndDate },
+	"numberDays":           func(o *Offer) uint64 { return o.NumberDays },
+	"mostSpecificRegionID": func(o *Offer) uint64 { return o.MostSpecificRegionID },
+}
+
+// SortKey orders offers by a numeric attribute.
+type SortKey struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// ParseSortOrder parses the sortOrder query parameter of the form <field>-asc or <field>-desc.
+// Unknown fields are not sorted on.
+func ParseSortOrder(sortOrder string) []SortKey {
+	i := strings.LastIndex(sortOrder, "-")
+	if i < 0 {
+		return nil
+	}
+	field, direction := sortOrder[:i], sortOrder[i+1:]
+	if _, ok := NumericAttributes[field]; !ok || (direction != "asc" && direction != "desc") {
+		return nil
+	}
+	return []SortKey{{Field: field, Desc: direction == "desc"}}
+}
+
+// SortOffers sorts the offers by the keys. Offers with equal keys are ordered by their lexicographical smaller ID first.
+func SortOffers(offers []*Offer, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	values := make([]func(o *Offer) uint64, len(keys))
+	for i, key := range keys {
+		values[i] = NumericAttributes[key.Field]
+	}
+
+	sort.Slice(offers, func(i, j int) bool {
+		for k, value := range values {
+			a, b := value(offers[i]), value(offers[j])
+			if a != b {
+				return (a < b) != keys[k].Desc
+			}
+		}
+		return bytes.Compare(offers[i].ID[:], offers[j].ID[:]) < 0
+	})
+}
@@ -14,8 +14,17 @@ type Region struct {
 	SubRegions []Region `json:"subRegions"`
 }
 
+// RootRegionID is the region all other regions are subregions of
+const RootRegionID = 0
+
 var SpecificRegionToAnchestor map[int32][]int32
 
+// RegionsByID indexes every region of the tree (inner and leaf nodes) by its id
+var RegionsByID map[int32]*Region
+
+// RegionDepth is the depth of every region in the tree, the root has depth 0
+var RegionDepth map[int32]int
+
 func InitRegions() {
 	var region Region
 	if err := json.Unmarshal([]byte(jsonRegion), &region); err != nil {
@@ -24,6 +33,32 @@ func InitRegions() {
 
 	SpecificRegionToAnchestor = make(map[int32][]int32)
 	region.ToAncestorMap(SpecificRegionToAnchestor, []int32{})
+
+	RegionsByID = make(map[int32]*Region)
+	RegionDepth = make(map[int32]int)
+	region.index(0)
+}
+
+func (region *Region) index(depth int) {
+	RegionsByID[region.Id] = region
+	RegionDepth[region.Id] = depth
+	for i := range region.SubRegions {
+		region.SubRegions[i].index(depth + 1)
+	}
+}
+
+// SubRegionOf returns the direct child of regionID that contains the leaf region
+// specificRegionID. ok is false if regionID is a leaf or not an ancestor of it.
+func SubRegionOf(regionID int32, specificRegionID int32) (child int32, ok bool) {
+	depth, exists := RegionDepth[regionID]
+	if !exists {
+		return 0, false
+	}
+	ancestors := SpecificRegionToAnchestor[specificRegionID]
+	if depth+1 >= len(ancestors) || ancestors[depth] != regionID {
+		return 0, false
+	}
+	return ancestors[depth+1], true
 }
 
 func (region *Region) ToAncestorMap(ancestorMap map[int32][]int32, ancestors []int32) {
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// Above this number of values quantiles are estimated with a sketch instead of sorting all values
+const exactQuantileLimit = 1 << 16
+
+// Relative error of the estimated quantiles
+const quantileRelativeError = 0.01
+
+var sketchGamma = (1 + quantileRelativeError) / (1 - quantileRelativeError)
+var sketchLogGamma = math.Log(sketchGamma)
+
+// Stats represents summary statistics of an offer attribute.
+type Stats struct {
+	Count  uint64  `json:"count"`
+	Min    uint64  `json:"min"`
+	Max    uint64  `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median uint64  `json:"median"`
+	P90    uint64  `json:"p90"`
+}
+
+// StatsSummary represents the statistics of the offers matching all filters.
+type StatsSummary struct {
+	Price          Stats `json:"price"`
+	FreeKilometers Stats `json:"freeKilometers"`
+}
+
+// Summary aggregates the statistics of an attribute in a single pass.
+// Quantiles are exact up to exactQuantileLimit values and have a relative error of
+// at most quantileRelativeError above it.
+type Summary struct {
+	Value func(offer *Offer) uint64
+
+	count uint64
+	min   uint64
+	max   uint64
+	sum   float64
+
+	exact  []uint64
+	zeros  uint64
+	sketch map[int]uint64
+}
+
+func NewSummary(value func(offer *Offer) uint64) *Summary {
+	return &Summary{Value: value, min: math.MaxUint64}
+}
+
+func (s *Summary) Add(offer *Offer) {
+	v := s.Value(offer)
+	s.count++
+	s.sum += float64(v)
+	s.min = min(s.min, v)
+	s.max = max(s.max, v)
+
+	if s.sketch == nil {
+		s.exact = append(s.exact, v)
+		if len(s.exact) <= exactQuantileLimit {
+			return
+		}
+		// Too many values, switch to the sketch
+		s.sketch = make(map[int]uint64)
+		for _, e := range s.exact {
+			s.addToSketch(e)
+		}
+		s.exact = nil
+		return
+	}
+	s.addToSketch(v)
+}
+
+func (s *Summary) addToSketch(v uint64) {
+	if v == 0 {
+		s.zeros++
+		return
+	}
+	s.sketch[int(math.Ceil(math.Log(float64(v))/sketchLogGamma))]++
+}
+
+// Quantile returns the value with the nearest rank for q in [0, 1].
+func (s *Summary) Quantile(q float64) uint64 {
+	if s.count == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(s.count)))
+	rank = max(rank, 1)
+
+	if s.sketch == nil {
+		sort.Slice(s.exact, func(i, j int) bool { return s.exact[i] < s.exact[j] })
+		return s.exact[rank-1]
+	}
+
+	if rank <= s.zeros {
+		return 0
+	}
+	keys := make([]int, 0, len(s.sketch))
+	for k := range s.sketch {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	seen := s.zeros
+	for _, k := range keys {
+		seen += s.sketch[k]
+		if seen >= rank {
+			estimate := uint64(math.Round(2 * math.Pow(sketchGamma, float64(k)) / (sketchGamma + 1)))
+			return min(max(estimate, s.min), s.max)
+		}
+	}
+	return s.max
+}
+
+func (s *Summary) Stats() Stats {
+	if s.count == 0 {
+		return Stats{}
+	}
+	return Stats{
+		Count:  s.count,
+		Min:    s.min,
+		Max:    s.max,
+		Mean:   s.sum / float64(s.count),
+		Median: s.Quantile(0.5),
+		P90:    s.Quantile(0.9),
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSummarySketchQuantiles(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	values := make([]uint64, 0, 3*exactQuantileLimit)
+	for range cap(values) {
+		// Skewed like prices, from 1 to about 10^6 cents
+		values = append(values, uint64(math.Exp(rng.Float64()*math.Log(1e6)))+1)
+	}
+
+	s := NewSummary(OfferPrice)
+	for _, v := range values {
+		s.Add(&Offer{Price: v})
+	}
+	if s.sketch == nil {
+		t.Fatalf("%d values are not estimated with the sketch", len(values))
+	}
+
+	slices.Sort(values)
+	stats := s.Stats()
+	for _, tt := range []struct {
+		name     string
+		q        float64
+		estimate uint64
+	}{
+		{"median", 0.5, stats.Median},
+		{"p90", 0.9, stats.P90},
+		{"p99", 0.99, s.Quantile(0.99)},
+	} {
+		exact := values[int(math.Ceil(tt.q*float64(len(values))))-1]
+		// The estimate is rounded to an integer
+		tolerance := quantileRelativeError + 0.5/float64(exact)
+		if relErr := math.Abs(float64(tt.estimate)-float64(exact)) / float64(exact); relErr > tolerance {
+			t.Errorf("%s = %d, exact %d, relative error %.4f above %.4f", tt.name, tt.estimate, exact, relErr, tolerance)
+		}
+	}
+	if stats.Count != uint64(len(values)) || stats.Min != values[0] || stats.Max != values[len(values)-1] {
+		t.Errorf("Stats() = %+v, want count %d, min %d and max %d", stats, len(values), values[0], values[len(values)-1])
+	}
+}
@@ -0,0 +1,240 @@
+package recorder
+
+import (
+	"check_republic/models"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeFormat formats the timestamps of the log in UTC with milliseconds
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// LogEntry is a line of the log. Entries written by the server have the HTTP method as request type.
+type LogEntry struct {
+	RequestType string `json:"requestType"`
+	Timestamp   string `json:"timestamp"`
+	Log         Log    `json:"log"`
+}
+
+// Log is a recorded request. The duration is in ms.
+type Log struct {
+	ID             string        `json:"id"`
+	StartTime      string        `json:"start_time"`
+	Duration       float64       `json:"duration"`
+	WriteConfig    *WriteConfig  `json:"write_config,omitempty"`
+	ExpectedResult *Result       `json:"expected_result,omitempty"`
+	ActualResult   *Result       `json:"actual_result,omitempty"`
+	SearchConfig   *SearchConfig `json:"search_config,omitempty"`
+}
+
+type WriteConfig struct {
+	ID     string  `json:"ID"`
+	Offers []Offer `json:"Offers"`
+}
+
+// Offer is a posted offer. Data is only set by the server, so the offers can be posted again.
+type Offer struct {
+	OfferID        string `json:"OfferID"`
+	RegionID       int    `json:"RegionID"`
+	CarType        string `json:"CarType"`
+	NumberDays     int    `json:"NumberDays"`
+	NumberSeats    int    `json:"NumberSeats"`
+	StartTimestamp string `json:"StartTimestamp"`
+	EndTimestamp   string `json:"EndTimestamp"`
+	Price          int    `json:"Price"`
+	HasVollkasko   bool   `json:"HasVollkasko"`
+	FreeKilometers int    `json:"FreeKilometers"`
+	Data           string `json:"Data,omitempty"`
+}
+
+type Result struct {
+	Offers              []OfferResult  `json:"Offers"`
+	CarTypeCounts       map[string]int `json:"CarTypeCounts"`
+	FreeKilometerRanges []RangeCount   `json:"FreeKilometerRanges"`
+	PriceRanges         []RangeCount   `json:"PriceRanges"`
+	SeatsCounts         map[string]int `json:"SeatsCounts"`
+	VollkaskoCount      map[string]int `json:"VollkaskoCount"`
+}
+
+type OfferResult struct {
+	OfferID       string `json:"OfferID"`
+	IsDataCorrect bool   `json:"IsDataCorrect"`
+}
+
+type RangeCount struct {
+	Start int `json:"Start"`
+	End   int `json:"End"`
+	Count int `json:"Count"`
+}
+
+type SearchConfig struct {
+	ID                string     `json:"ID"`
+	RegionID          int        `json:"RegionID"`
+	StartRange        string     `json:"StartRange"`
+	EndRange          string     `json:"EndRange"`
+	NumberDays        int        `json:"NumberDays"`
+	CarType           *string    `json:"CarType,omitempty"`
+	OnlyVollkasko     *bool      `json:"OnlyVollkasko,omitempty"`
+	MinFreeKilometer  *int       `json:"MinFreeKilometer,omitempty"`
+	MinNumberSeats    *int       `json:"MinNumberSeats,omitempty"`
+	MinPrice          *int       `json:"MinPrice,omitempty"`
+	MaxPrice          *int       `json:"MaxPrice,omitempty"`
+	Pagination        Pagination `json:"Pagination"`
+	Order             string     `json:"Order"`
+	PriceBucketWidth  int        `json:"PriceBucketWidth"`
+	FreeKmBucketWidth int        `json:"FreeKmBucketWidth"`
+}
+
+type Pagination struct {
+	Page     int `json:"Page"`
+	PageSize int `json:"PageSize"`
+}
+
+// FormatTime formats a timestamp in ms since UNIX epoch
+func FormatTime(ms uint64) string {
+	return time.UnixMilli(int64(ms)).UTC().Format(TimeFormat)
+}
+
+// ParseTime parses a timestamp of the log to ms since UNIX epoch
+func ParseTime(s string) (uint64, error) {
+	t, err := time.Parse(TimeFormat, s)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(t.UnixMilli()), nil
+}
+
+func NewWriteConfig(id string, offers *models.Offers) *WriteConfig {
+	ret := &WriteConfig{ID: id, Offers: make([]Offer, 0, len(offers.Offers))}
+	for _, o := range offers.Offers {
+		ret.Offers = append(ret.Offers, Offer{
+			OfferID:        o.ID.String(),
+			RegionID:       int(o.MostSpecificRegionID),
+			CarType:        o.CarType,
+			NumberDays:     int((o.EndDate - o.StartDate) / models.MsFactor),
+			NumberSeats:    int(o.NumberSeats),
+			StartTimestamp: FormatTime(o.StartDate),
+			EndTimestamp:   FormatTime(o.EndDate),
+			Price:          int(o.Price),
+			HasVollkasko:   o.HasVollkasko,
+			FreeKilometers: int(o.FreeKilometers),
+			Data:           o.Data,
+		})
+	}
+	return ret
+}
+
+// ToOffers converts the offers back to the body of POST /api/offers
+func (w *WriteConfig) ToOffers() (*models.Offers, error) {
+	ret := &models.Offers{Offers: make([]*models.Offer, 0, len(w.Offers))}
+	for _, o := range w.Offers {
+		id, err := uuid.Parse(o.OfferID)
+		if err != nil {
+			return nil, err
+		}
+		start, err := ParseTime(o.StartTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseTime(o.EndTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		ret.Offers = append(ret.Offers, &models.Offer{
+			ID:                   id,
+			Data:                 o.Data,
+			MostSpecificRegionID: uint64(o.RegionID),
+			StartDate:            start,
+			EndDate:              end,
+			NumberSeats:          uint64(o.NumberSeats),
+			Price:                uint64(o.Price),
+			CarType:              o.CarType,
+			HasVollkasko:         o.HasVollkasko,
+			FreeKilometers:       uint64(o.FreeKilometers),
+		})
+	}
+	return ret, nil
+}
+
+// Query converts the search back to the query parameters of GET /api/offers
+func (s *SearchConfig) Query() (url.Values, error) {
+	start, err := ParseTime(s.StartRange)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseTime(s.EndRange)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("regionID", strconv.Itoa(s.RegionID))
+	query.Set("timeRangeStart", strconv.FormatUint(start, 10))
+	query.Set("timeRangeEnd", strconv.FormatUint(end, 10))
+	query.Set("numberDays", strconv.Itoa(s.NumberDays))
+	query.Set("sortOrder", s.Order)
+	query.Set("page", strconv.Itoa(s.Pagination.Page))
+	query.Set("pageSize", strconv.Itoa(s.Pagination.PageSize))
+	query.Set("priceRangeWidth", strconv.Itoa(s.PriceBucketWidth))
+	query.Set("minFreeKilometerWidth", strconv.Itoa(s.FreeKmBucketWidth))
+	if s.CarType != nil {
+		query.Set("carType", *s.CarType)
+	}
+	if s.OnlyVollkasko != nil {
+		query.Set("onlyVollkasko", strconv.FormatBool(*s.OnlyVollkasko))
+	}
+	setOptional(query, "minFreeKilometer", s.MinFreeKilometer)
+	setOptional(query, "minNumberSeats", s.MinNumberSeats)
+	setOptional(query, "minPrice", s.MinPrice)
+	setOptional(query, "maxPrice", s.MaxPrice)
+	return query, nil
+}
+
+func setOptional(query url.Values, key string, v *int) {
+	if v != nil {
+		query.Set(key, strconv.Itoa(*v))
+	}
+}
+
+// NewResult converts a search response, aggregations that were not computed are empty.
+// isDataCorrect checks the data of the returned offers.
+func NewResult(dto *models.DTO, isDataCorrect func(o *models.OfferDTO) bool) *Result {
+	ret := &Result{
+		Offers:              make([]OfferResult, 0, len(dto.Offers)),
+		CarTypeCounts:       make(map[string]int, len(dto.CarTypeCounts)),
+		FreeKilometerRanges: convertRanges(dto.FreeKilometerRange),
+		PriceRanges:         convertRanges(dto.PriceRanges),
+		SeatsCounts:         map[string]int{},
+		VollkaskoCount:      map[string]int{"trueCount": 0, "falseCount": 0},
+	}
+	for _, o := range dto.Offers {
+		ret.Offers = append(ret.Offers, OfferResult{OfferID: o.ID, IsDataCorrect: isDataCorrect(o)})
+	}
+	for carType, count := range dto.CarTypeCounts {
+		ret.CarTypeCounts[carType] = int(count)
+	}
+	if dto.SeatsCount != nil {
+		for _, s := range *dto.SeatsCount {
+			ret.SeatsCounts[strconv.FormatUint(s.NumberSeats, 10)] = int(s.Count)
+		}
+	}
+	if dto.VollkaskoCount != nil {
+		ret.VollkaskoCount["trueCount"] = int(dto.VollkaskoCount.TrueCount)
+		ret.VollkaskoCount["falseCount"] = int(dto.VollkaskoCount.FalseCount)
+	}
+	return ret
+}
+
+func convertRanges(ranges *[]models.HistogramRange) []RangeCount {
+	ret := []RangeCount{}
+	if ranges == nil {
+		return ret
+	}
+	for _, r := range *ranges {
+		ret = append(ret, RangeCount{Start: int(r.Start), End: int(r.End), Count: int(r.Count)})
+	}
+	return ret
+}
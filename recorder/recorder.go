@@ -0,0 +1,220 @@
+// Package recorder records the requests of the offer API and their responses to a JSONL file,
+// so the traffic can be replayed with cmd/replay and compared with cmd.
+package recorder
+
+import (
+	"bytes"
+	"check_republic/accesslog"
+	"check_republic/api"
+	"check_republic/models"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const offersKey = "recorder.offers"
+
+// searchParameters are the query parameters that a SearchConfig holds, the parameters of the spec search.
+// The handler reads them through the same api.GetOffersParams.
+var searchParameters = formParameters(reflect.TypeFor[api.GetOffersParams]())
+
+func formParameters(t reflect.Type) map[string]bool {
+	ret := make(map[string]bool, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("form"), ",")
+		ret[name] = true
+	}
+	return ret
+}
+
+// recordedRoutes have the same responses as /api/offers
+var recordedRoutes = map[string]bool{"/api/offers": true, "/api/v1/offers": true}
+
+// unrecordedRoutes search or write offers, but the log format has no entry for them
+var unrecordedRoutes = map[string]bool{
+	"GET /api/v2/offers":      true,
+	"POST /api/offers/search": true,
+	"POST /api/offers/bulk":   true,
+	"POST /api/offers/import": true,
+	"POST /graphql":           true,
+}
+
+// Recorder appends a LogEntry per recorded request to a file
+type Recorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func New(filename string) (*Recorder, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// Middleware records the successful POST, GET and DELETE requests of /api/offers and the searches of
+// /api/v1/offers. The other routes that search or write offers are not recorded and logged with a warning.
+// Search responses are only recorded as JSON, it has to run after the gzip middleware to see the
+// uncompressed responses.
+func (r *Recorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !recordedRoutes[c.FullPath()] {
+			c.Next()
+			if unrecordedRoutes[c.Request.Method+" "+c.FullPath()] {
+				slog.Warn("Request not recorded, the log format has no entry for the route", "method", c.Request.Method, "route", c.FullPath())
+			}
+			return
+		}
+
+		start := time.Now()
+		var writer *responseWriter
+		if c.Request.Method == http.MethodGet {
+			writer = &responseWriter{ResponseWriter: c.Writer}
+			c.Writer = writer
+		}
+
+		c.Next()
+
+		// Rejected requests fail again when they are replayed
+		if c.Writer.Status() != http.StatusOK {
+			return
+		}
+		id := c.Writer.Header().Get(accesslog.RequestIDHeader)
+		entry := LogEntry{
+			RequestType: c.Request.Method,
+			Log: Log{
+				ID:        id,
+				StartTime: start.UTC().Format(TimeFormat),
+				Duration:  float64(time.Since(start).Microseconds()) / 1000,
+			},
+		}
+		switch c.Request.Method {
+		case http.MethodPost:
+			offers, ok := c.Get(offersKey)
+			if !ok {
+				return
+			}
+			entry.Log.WriteConfig = NewWriteConfig(id, offers.(*models.Offers))
+		case http.MethodGet:
+			if unsupported := unsupportedParameters(c.Request.URL.Query()); len(unsupported) > 0 {
+				slog.Warn("Search not recorded, the log format cannot hold its parameters", "requestID", id, "parameters", unsupported)
+				return
+			}
+			entry.Log.SearchConfig = newSearchConfig(id, c)
+			if !strings.HasPrefix(writer.Header().Get("Content-Type"), binding.MIMEJSON) {
+				break
+			}
+			var dto models.DTO
+			if err := json.Unmarshal(writer.body.Bytes(), &dto); err != nil {
+				slog.Error("Error recording response", "error", err)
+				break
+			}
+			// The recorded response is the expected one
+			entry.Log.ExpectedResult = NewResult(&dto, func(*models.OfferDTO) bool { return true })
+		case http.MethodDelete:
+			// Only the request itself is recorded
+		default:
+			return
+		}
+		r.write(&entry)
+	}
+}
+
+// SetOffers passes the offers bound by the handler of POST /api/offers to the recorder
+func SetOffers(c *gin.Context, offers *models.Offers) {
+	c.Set(offersKey, offers)
+}
+
+func (r *Recorder) write(entry *LogEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry.Timestamp = time.Now().UTC().Format(TimeFormat)
+	if err := r.encoder.Encode(entry); err != nil {
+		slog.Error("Error recording request", "error", err)
+	}
+}
+
+// unsupportedParameters returns the parameters of a search that a SearchConfig cannot hold,
+// including multiple car types
+func unsupportedParameters(query url.Values) []string {
+	var ret []string
+	for key, values := range query {
+		if !searchParameters[key] || len(values) > 1 || (key == "carType" && strings.Contains(values[0], ",")) {
+			ret = append(ret, key)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// newSearchConfig converts the query parameters of a search, parameters that cannot be parsed are left out
+func newSearchConfig(id string, c *gin.Context) *SearchConfig {
+	ret := &SearchConfig{
+		ID:                id,
+		RegionID:          queryInt(c, "regionID"),
+		StartRange:        FormatTime(uint64(queryInt(c, "timeRangeStart"))),
+		EndRange:          FormatTime(uint64(queryInt(c, "timeRangeEnd"))),
+		NumberDays:        queryInt(c, "numberDays"),
+		MinFreeKilometer:  queryIntPtr(c, "minFreeKilometer"),
+		MinNumberSeats:    queryIntPtr(c, "minNumberSeats"),
+		MinPrice:          queryIntPtr(c, "minPrice"),
+		MaxPrice:          queryIntPtr(c, "maxPrice"),
+		Pagination:        Pagination{Page: queryInt(c, "page"), PageSize: queryInt(c, "pageSize")},
+		Order:             c.Query("sortOrder"),
+		PriceBucketWidth:  queryInt(c, "priceRangeWidth"),
+		FreeKmBucketWidth: queryInt(c, "minFreeKilometerWidth"),
+	}
+	if carType, ok := c.GetQuery("carType"); ok {
+		ret.CarType = &carType
+	}
+	if onlyVollkasko, err := strconv.ParseBool(c.Query("onlyVollkasko")); err == nil {
+		ret.OnlyVollkasko = &onlyVollkasko
+	}
+	return ret
+}
+
+func queryInt(c *gin.Context, key string) int {
+	v, _ := strconv.Atoi(c.Query(key))
+	return v
+}
+
+func queryIntPtr(c *gin.Context, key string) *int {
+	v, err := strconv.Atoi(c.Query(key))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// responseWriter keeps a copy of the response body
+type responseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
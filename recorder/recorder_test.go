@@ -0,0 +1,114 @@
+package recorder
+
+import (
+	"bufio"
+	"check_republic/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	filename := filepath.Join(t.TempDir(), "traffic.jsonl")
+	rec, err := New(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(rec.Middleware())
+	r.POST("/api/offers", func(c *gin.Context) {
+		if c.Query("reject") != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offer"})
+			return
+		}
+		SetOffers(c, &models.Offers{Offers: []*models.Offer{{CarType: "small", StartDate: 0, EndDate: models.MsFactor, Data: "d"}}})
+		c.String(http.StatusOK, "Offer created")
+	})
+	search := func(c *gin.Context) {
+		c.JSON(http.StatusOK, models.DTO{Offers: []*models.OfferDTO{{ID: "1"}}})
+	}
+	r.GET("/api/offers", search)
+	r.GET("/api/v1/offers", search)
+	r.GET("/api/v2/offers", search)
+
+	requests := []struct {
+		method string
+		target string
+	}{
+		{http.MethodPost, "/api/offers"},
+		{http.MethodPost, "/api/offers?reject=true"},
+		{http.MethodGet, "/api/offers?regionID=1&carType=small&timeRangeStart=0&timeRangeEnd=86400000"},
+		{http.MethodGet, "/api/offers?regionID=1&carType=small,family"},
+		{http.MethodGet, "/api/offers?regionID=1&carType=small&carType=family"},
+		{http.MethodGet, "/api/offers?regionID=1&numberSeats=4"},
+		{http.MethodGet, "/api/v1/offers?regionID=2"},
+		{http.MethodGet, "/api/v2/offers?regionID=3"},
+	}
+	for _, req := range requests {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.target, strings.NewReader("")))
+	}
+	rec.Close()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var entries []LogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	// The rejected POST, the searches with parameters of the extensions and the version 2 search are not recorded
+	if len(entries) != 3 {
+		t.Fatalf("%d entries recorded, want 3: %+v", len(entries), entries)
+	}
+	if w := entries[0].Log.WriteConfig; w == nil || len(w.Offers) != 1 || w.Offers[0].NumberDays != 1 || w.Offers[0].Data != "d" {
+		t.Errorf("write config = %+v", w)
+	}
+	if s := entries[1].Log.SearchConfig; s == nil || s.RegionID != 1 || s.CarType == nil || *s.CarType != "small" || s.EndRange != "1970-01-02T00:00:00.000Z" {
+		t.Errorf("search config = %+v", s)
+	}
+	if e := entries[1].Log.ExpectedResult; e == nil || len(e.Offers) != 1 || e.Offers[0].OfferID != "1" {
+		t.Errorf("expected result = %+v", e)
+	}
+	if s := entries[2].Log.SearchConfig; s == nil || s.RegionID != 2 {
+		t.Errorf("search config of /api/v1/offers = %+v", s)
+	}
+}
+
+// TestSearchConfigParameters checks that a SearchConfig holds every parameter of the spec search
+func TestSearchConfigParameters(t *testing.T) {
+	carType, onlyVollkasko, n := "small", true, 1
+	config := SearchConfig{
+		StartRange: FormatTime(0), EndRange: FormatTime(0), CarType: &carType, OnlyVollkasko: &onlyVollkasko,
+		MinFreeKilometer: &n, MinNumberSeats: &n, MinPrice: &n, MaxPrice: &n,
+	}
+	query, err := config.Query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range query {
+		if !searchParameters[key] {
+			t.Errorf("%s is not a parameter of the spec search", key)
+		}
+	}
+	for key := range searchParameters {
+		if _, ok := query[key]; !ok {
+			t.Errorf("parameter %s is not held by a SearchConfig", key)
+		}
+	}
+}
@@ -0,0 +1,1333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: offers.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Offer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data                 string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MostSpecificRegionId uint64 `protobuf:"varint,3,opt,name=most_specific_region_id,json=mostSpecificRegionId,proto3" json:"most_specific_region_id,omitempty"`
+	StartDate            uint64 `protobuf:"varint,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate              uint64 `protobuf:"varint,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	NumberSeats          uint64 `protobuf:"varint,6,opt,name=number_seats,json=numberSeats,proto3" json:"number_seats,omitempty"`
+	Price                uint64 `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`
+	CarType              string `protobuf:"bytes,8,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	HasVollkasko         bool   `protobuf:"varint,9,opt,name=has_vollkasko,json=hasVollkasko,proto3" json:"has_vollkasko,omitempty"`
+	FreeKilometers       uint64 `protobuf:"varint,10,opt,name=free_kilometers,json=freeKilometers,proto3" json:"free_kilometers,omitempty"`
+}
+
+func (x *Offer) Reset() {
+	*x = Offer{}
+	mi := &file_offers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Offer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Offer) ProtoMessage() {}
+
+func (x *Offer) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Offer.ProtoReflect.Descriptor instead.
+func (*Offer) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Offer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Offer) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Offer) GetMostSpecificRegionId() uint64 {
+	if x != nil {
+		return x.MostSpecificRegionId
+	}
+	return 0
+}
+
+func (x *Offer) GetStartDate() uint64 {
+	if x != nil {
+		return x.StartDate
+	}
+	return 0
+}
+
+func (x *Offer) GetEndDate() uint64 {
+	if x != nil {
+		return x.EndDate
+	}
+	return 0
+}
+
+func (x *Offer) GetNumberSeats() uint64 {
+	if x != nil {
+		return x.NumberSeats
+	}
+	return 0
+}
+
+func (x *Offer) GetPrice() uint64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Offer) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *Offer) GetHasVollkasko() bool {
+	if x != nil {
+		return x.HasVollkasko
+	}
+	return false
+}
+
+func (x *Offer) GetFreeKilometers() uint64 {
+	if x != nil {
+		return x.FreeKilometers
+	}
+	return 0
+}
+
+type CreateOffersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offers []*Offer `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+}
+
+func (x *CreateOffersRequest) Reset() {
+	*x = CreateOffersRequest{}
+	mi := &file_offers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOffersRequest) ProtoMessage() {}
+
+func (x *CreateOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOffersRequest.ProtoReflect.Descriptor instead.
+func (*CreateOffersRequest) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateOffersRequest) GetOffers() []*Offer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type OfferError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the offer in the stream, starting at 1
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *OfferError) Reset() {
+	*x = OfferError{}
+	mi := &file_offers_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferError) ProtoMessage() {}
+
+func (x *OfferError) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferError.ProtoReflect.Descriptor instead.
+func (*OfferError) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OfferError) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *OfferError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CreateOffersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Received uint64        `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Created  uint64        `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Failed   uint64        `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors   []*OfferError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *CreateOffersResponse) Reset() {
+	*x = CreateOffersResponse{}
+	mi := &file_offers_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOffersResponse) ProtoMessage() {}
+
+func (x *CreateOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOffersResponse.ProtoReflect.Descriptor instead.
+func (*CreateOffersResponse) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateOffersResponse) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *CreateOffersResponse) GetCreated() uint64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *CreateOffersResponse) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CreateOffersResponse) GetErrors() []*OfferError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SearchOffersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RegionId              int32    `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	TimeRangeStart        uint64   `protobuf:"varint,2,opt,name=time_range_start,json=timeRangeStart,proto3" json:"time_range_start,omitempty"`
+	TimeRangeEnd          uint64   `protobuf:"varint,3,opt,name=time_range_end,json=timeRangeEnd,proto3" json:"time_range_end,omitempty"`
+	NumberDays            uint64   `protobuf:"varint,4,opt,name=number_days,json=numberDays,proto3" json:"number_days,omitempty"`
+	SortOrder             string   `protobuf:"bytes,5,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Page                  uint64   `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize              uint64   `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PriceRangeWidth       uint64   `protobuf:"varint,8,opt,name=price_range_width,json=priceRangeWidth,proto3" json:"price_range_width,omitempty"`
+	MinFreeKilometerWidth uint64   `protobuf:"varint,9,opt,name=min_free_kilometer_width,json=minFreeKilometerWidth,proto3" json:"min_free_kilometer_width,omitempty"`
+	MinNumberSeats        *uint64  `protobuf:"varint,10,opt,name=min_number_seats,json=minNumberSeats,proto3,oneof" json:"min_number_seats,omitempty"`
+	NumberSeats           []uint64 `protobuf:"varint,11,rep,packed,name=number_seats,json=numberSeats,proto3" json:"number_seats,omitempty"`
+	MinPrice              *uint64  `protobuf:"varint,12,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice              *uint64  `protobuf:"varint,13,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	CarTypes              []string `protobuf:"bytes,14,rep,name=car_types,json=carTypes,proto3" json:"car_types,omitempty"`
+	OnlyVollkasko         *bool    `protobuf:"varint,15,opt,name=only_vollkasko,json=onlyVollkasko,proto3,oneof" json:"only_vollkasko,omitempty"`
+	MinFreeKilometer      *uint64  `protobuf:"varint,16,opt,name=min_free_kilometer,json=minFreeKilometer,proto3,oneof" json:"min_free_kilometer,omitempty"`
+	MinPricePerDay        *uint64  `protobuf:"varint,17,opt,name=min_price_per_day,json=minPricePerDay,proto3,oneof" json:"min_price_per_day,omitempty"`
+	MaxPricePerDay        *uint64  `protobuf:"varint,18,opt,name=max_price_per_day,json=maxPricePerDay,proto3,oneof" json:"max_price_per_day,omitempty"`
+}
+
+func (x *SearchOffersRequest) Reset() {
+	*x = SearchOffersRequest{}
+	mi := &file_offers_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOffersRequest) ProtoMessage() {}
+
+func (x *SearchOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOffersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOffersRequest) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchOffersRequest) GetRegionId() int32 {
+	if x != nil {
+		return x.RegionId
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetTimeRangeStart() uint64 {
+	if x != nil {
+		return x.TimeRangeStart
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetTimeRangeEnd() uint64 {
+	if x != nil {
+		return x.TimeRangeEnd
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetNumberDays() uint64 {
+	if x != nil {
+		return x.NumberDays
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *SearchOffersRequest) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetPriceRangeWidth() uint64 {
+	if x != nil {
+		return x.PriceRangeWidth
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetMinFreeKilometerWidth() uint64 {
+	if x != nil {
+		return x.MinFreeKilometerWidth
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetMinNumberSeats() uint64 {
+	if x != nil && x.MinNumberSeats != nil {
+		return *x.MinNumberSeats
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetNumberSeats() []uint64 {
+	if x != nil {
+		return x.NumberSeats
+	}
+	return nil
+}
+
+func (x *SearchOffersRequest) GetMinPrice() uint64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetMaxPrice() uint64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetCarTypes() []string {
+	if x != nil {
+		return x.CarTypes
+	}
+	return nil
+}
+
+func (x *SearchOffersRequest) GetOnlyVollkasko() bool {
+	if x != nil && x.OnlyVollkasko != nil {
+		return *x.OnlyVollkasko
+	}
+	return false
+}
+
+func (x *SearchOffersRequest) GetMinFreeKilometer() uint64 {
+	if x != nil && x.MinFreeKilometer != nil {
+		return *x.MinFreeKilometer
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetMinPricePerDay() uint64 {
+	if x != nil && x.MinPricePerDay != nil {
+		return *x.MinPricePerDay
+	}
+	return 0
+}
+
+func (x *SearchOffersRequest) GetMaxPricePerDay() uint64 {
+	if x != nil && x.MaxPricePerDay != nil {
+		return *x.MaxPricePerDay
+	}
+	return 0
+}
+
+type SearchResultOffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SearchResultOffer) Reset() {
+	*x = SearchResultOffer{}
+	mi := &file_offers_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResultOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResultOffer) ProtoMessage() {}
+
+func (x *SearchResultOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResultOffer.ProtoReflect.Descriptor instead.
+func (*SearchResultOffer) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchResultOffer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchResultOffer) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type HistogramRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start uint64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *HistogramRange) Reset() {
+	*x = HistogramRange{}
+	mi := &file_offers_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistogramRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramRange) ProtoMessage() {}
+
+func (x *HistogramRange) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramRange.ProtoReflect.Descriptor instead.
+func (*HistogramRange) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HistogramRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *HistogramRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *HistogramRange) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SeatsCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NumberSeats uint64 `protobuf:"varint,1,opt,name=number_seats,json=numberSeats,proto3" json:"number_seats,omitempty"`
+	Count       uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SeatsCount) Reset() {
+	*x = SeatsCount{}
+	mi := &file_offers_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatsCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatsCount) ProtoMessage() {}
+
+func (x *SeatsCount) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatsCount.ProtoReflect.Descriptor instead.
+func (*SeatsCount) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SeatsCount) GetNumberSeats() uint64 {
+	if x != nil {
+		return x.NumberSeats
+	}
+	return 0
+}
+
+func (x *SeatsCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type VollkaskoCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrueCount  uint64 `protobuf:"varint,1,opt,name=true_count,json=trueCount,proto3" json:"true_count,omitempty"`
+	FalseCount uint64 `protobuf:"varint,2,opt,name=false_count,json=falseCount,proto3" json:"false_count,omitempty"`
+}
+
+func (x *VollkaskoCount) Reset() {
+	*x = VollkaskoCount{}
+	mi := &file_offers_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VollkaskoCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VollkaskoCount) ProtoMessage() {}
+
+func (x *VollkaskoCount) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VollkaskoCount.ProtoReflect.Descriptor instead.
+func (*VollkaskoCount) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VollkaskoCount) GetTrueCount() uint64 {
+	if x != nil {
+		return x.TrueCount
+	}
+	return 0
+}
+
+func (x *VollkaskoCount) GetFalseCount() uint64 {
+	if x != nil {
+		return x.FalseCount
+	}
+	return 0
+}
+
+type SearchOffersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offers             []*SearchResultOffer `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	PriceRanges        []*HistogramRange    `protobuf:"bytes,2,rep,name=price_ranges,json=priceRanges,proto3" json:"price_ranges,omitempty"`
+	CarTypeCounts      map[string]uint64    `protobuf:"bytes,3,rep,name=car_type_counts,json=carTypeCounts,proto3" json:"car_type_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	SeatsCount         []*SeatsCount        `protobuf:"bytes,4,rep,name=seats_count,json=seatsCount,proto3" json:"seats_count,omitempty"`
+	FreeKilometerRange []*HistogramRange    `protobuf:"bytes,5,rep,name=free_kilometer_range,json=freeKilometerRange,proto3" json:"free_kilometer_range,omitempty"`
+	VollkaskoCount     *VollkaskoCount      `protobuf:"bytes,6,opt,name=vollkasko_count,json=vollkaskoCount,proto3" json:"vollkasko_count,omitempty"`
+	Meta               *PageMeta            `protobuf:"bytes,7,opt,name=meta,proto3" json:"meta,omitempty"`
+}
+
+func (x *SearchOffersResponse) Reset() {
+	*x = SearchOffersResponse{}
+	mi := &file_offers_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOffersResponse) ProtoMessage() {}
+
+func (x *SearchOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOffersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOffersResponse) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchOffersResponse) GetOffers() []*SearchResultOffer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetPriceRanges() []*HistogramRange {
+	if x != nil {
+		return x.PriceRanges
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetCarTypeCounts() map[string]uint64 {
+	if x != nil {
+		return x.CarTypeCounts
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetSeatsCount() []*SeatsCount {
+	if x != nil {
+		return x.SeatsCount
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetFreeKilometerRange() []*HistogramRange {
+	if x != nil {
+		return x.FreeKilometerRange
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetVollkaskoCount() *VollkaskoCount {
+	if x != nil {
+		return x.VollkaskoCount
+	}
+	return nil
+}
+
+func (x *SearchOffersResponse) GetMeta() *PageMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type PageMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of offers matching all filters
+	Total    uint64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Page     uint64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize uint64 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	HasNext  bool   `protobuf:"varint,4,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+}
+
+func (x *PageMeta) Reset() {
+	*x = PageMeta{}
+	mi := &file_offers_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageMeta) ProtoMessage() {}
+
+func (x *PageMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageMeta.ProtoReflect.Descriptor instead.
+func (*PageMeta) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PageMeta) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageMeta) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageMeta) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageMeta) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type DeleteOffersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteOffersRequest) Reset() {
+	*x = DeleteOffersRequest{}
+	mi := &file_offers_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOffersRequest) ProtoMessage() {}
+
+func (x *DeleteOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOffersRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOffersRequest) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{11}
+}
+
+type DeleteOffersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteOffersResponse) Reset() {
+	*x = DeleteOffersResponse{}
+	mi := &file_offers_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOffersResponse) ProtoMessage() {}
+
+func (x *DeleteOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOffersResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOffersResponse) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{12}
+}
+
+type GetRegionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRegionRequest) Reset() {
+	*x = GetRegionRequest{}
+	mi := &file_offers_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegionRequest) ProtoMessage() {}
+
+func (x *GetRegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegionRequest.ProtoReflect.Descriptor instead.
+func (*GetRegionRequest) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRegionRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Region struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int32     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SubRegions []*Region `protobuf:"bytes,3,rep,name=sub_regions,json=subRegions,proto3" json:"sub_regions,omitempty"`
+}
+
+func (x *Region) Reset() {
+	*x = Region{}
+	mi := &file_offers_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Region) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Region) ProtoMessage() {}
+
+func (x *Region) ProtoReflect() protoreflect.Message {
+	mi := &file_offers_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Region.ProtoReflect.Descriptor instead.
+func (*Region) Descriptor() ([]byte, []int) {
+	return file_offers_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Region) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Region) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Region) GetSubRegions() []*Region {
+	if x != nil {
+		return x.SubRegions
+	}
+	return nil
+}
+
+var File_offers_proto protoreflect.FileDescriptor
+
+var file_offers_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x22, 0xbe, 0x02, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x35, 0x0a, 0x17, 0x6d, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69,
+	0x66, 0x69, 0x63, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x6d, 0x6f, 0x73, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x63, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x63, 0x61, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x5f, 0x76,
+	0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x68, 0x61, 0x73, 0x56, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b, 0x6f, 0x12, 0x27, 0x0a, 0x0f,
+	0x66, 0x72, 0x65, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x72, 0x65, 0x65, 0x4b, 0x69, 0x6c, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x4a, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66,
+	0x65, 0x72, 0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x65, 0x72,
+	0x73, 0x22, 0x38, 0x0a, 0x0a, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9e, 0x01, 0x0a, 0x14,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x12, 0x38, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0xd1, 0x06, 0x0a,
+	0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x74, 0x69, 0x6d,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x61,
+	0x79, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x37,
+	0x0a, 0x18, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x15, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x4b, 0x69, 0x6c, 0x6f, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x2d, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x65,
+	0x61, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0b, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x69, 0x6e,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x08,
+	0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x61, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x0e, 0x6f, 0x6e,
+	0x6c, 0x79, 0x5f, 0x76, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b, 0x6f, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x03, 0x52, 0x0d, 0x6f, 0x6e, 0x6c, 0x79, 0x56, 0x6f, 0x6c, 0x6c, 0x6b, 0x61,
+	0x73, 0x6b, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x72,
+	0x65, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x04, 0x48, 0x04, 0x52, 0x10, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x4b, 0x69, 0x6c,
+	0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x11, 0x6d, 0x69, 0x6e,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x05, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x50, 0x65, 0x72, 0x44, 0x61, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x11, 0x6d, 0x61, 0x78,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x06, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x50, 0x65, 0x72, 0x44, 0x61, 0x79, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6d, 0x69,
+	0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x42, 0x0c,
+	0x0a, 0x0a, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6f,
+	0x6e, 0x6c, 0x79, 0x5f, 0x76, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b, 0x6f, 0x42, 0x15, 0x0a,
+	0x13, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79,
+	0x22, 0x37, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4e, 0x0a, 0x0e, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x45, 0x0a, 0x0a, 0x53, 0x65, 0x61,
+	0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x50, 0x0a, 0x0e, 0x56, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b, 0x6f, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x72, 0x75, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xe7, 0x04, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65,
+	0x72, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x47, 0x0a, 0x0c,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x65, 0x0a, 0x0f, 0x63, 0x61, 0x72, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d,
+	0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x61, 0x72, 0x54, 0x79,
+	0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x63,
+	0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0b,
+	0x73, 0x65, 0x61, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x56, 0x0a, 0x14, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6f, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x12, 0x66, 0x72, 0x65, 0x65, 0x4b, 0x69, 0x6c, 0x6f, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x76, 0x6f, 0x6c, 0x6c, 0x6b,
+	0x61, 0x73, 0x6b, 0x6f, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x56, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b,
+	0x6f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0e, 0x76, 0x6f, 0x6c, 0x6c, 0x6b, 0x61, 0x73, 0x6b,
+	0x6f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x1a, 0x40, 0x0a, 0x12, 0x43, 0x61,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6c, 0x0a, 0x08,
+	0x50, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x70, 0x61,
+	0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x22, 0x15, 0x0a, 0x13, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6b, 0x0a,
+	0x06, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x73,
+	0x75, 0x62, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0x92, 0x03, 0x0a, 0x06, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x67, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x29, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2a, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x65,
+	0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x29,
+	0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x29, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2a, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x2e, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x42,
+	0x14, 0x5a, 0x12, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_offers_proto_rawDescOnce sync.Once
+	file_offers_proto_rawDescData = file_offers_proto_rawDesc
+)
+
+func file_offers_proto_rawDescGZIP() []byte {
+	file_offers_proto_rawDescOnce.Do(func() {
+		file_offers_proto_rawDescData = protoimpl.X.CompressGZIP(file_offers_proto_rawDescData)
+	})
+	return file_offers_proto_rawDescData
+}
+
+var file_offers_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_offers_proto_goTypes = []any{
+	(*Offer)(nil),                // 0: checkrepublic.offers.Offer
+	(*CreateOffersRequest)(nil),  // 1: checkrepublic.offers.CreateOffersRequest
+	(*OfferError)(nil),           // 2: checkrepublic.offers.OfferError
+	(*CreateOffersResponse)(nil), // 3: checkrepublic.offers.CreateOffersResponse
+	(*SearchOffersRequest)(nil),  // 4: checkrepublic.offers.SearchOffersRequest
+	(*SearchResultOffer)(nil),    // 5: checkrepublic.offers.SearchResultOffer
+	(*HistogramRange)(nil),       // 6: checkrepublic.offers.HistogramRange
+	(*SeatsCount)(nil),           // 7: checkrepublic.offers.SeatsCount
+	(*VollkaskoCount)(nil),       // 8: checkrepublic.offers.VollkaskoCount
+	(*SearchOffersResponse)(nil), // 9: checkrepublic.offers.SearchOffersResponse
+	(*PageMeta)(nil),             // 10: checkrepublic.offers.PageMeta
+	(*DeleteOffersRequest)(nil),  // 11: checkrepublic.offers.DeleteOffersRequest
+	(*DeleteOffersResponse)(nil), // 12: checkrepublic.offers.DeleteOffersResponse
+	(*GetRegionRequest)(nil),     // 13: checkrepublic.offers.GetRegionRequest
+	(*Region)(nil),               // 14: checkrepublic.offers.Region
+	nil,                          // 15: checkrepublic.offers.SearchOffersResponse.CarTypeCountsEntry
+}
+var file_offers_proto_depIdxs = []int32{
+	0,  // 0: checkrepublic.offers.CreateOffersRequest.offers:type_name -> checkrepublic.offers.Offer
+	2,  // 1: checkrepublic.offers.CreateOffersResponse.errors:type_name -> checkrepublic.offers.OfferError
+	5,  // 2: checkrepublic.offers.SearchOffersResponse.offers:type_name -> checkrepublic.offers.SearchResultOffer
+	6,  // 3: checkrepublic.offers.SearchOffersResponse.price_ranges:type_name -> checkrepublic.offers.HistogramRange
+	15, // 4: checkrepublic.offers.SearchOffersResponse.car_type_counts:type_name -> checkrepublic.offers.SearchOffersResponse.CarTypeCountsEntry
+	7,  // 5: checkrepublic.offers.SearchOffersResponse.seats_count:type_name -> checkrepublic.offers.SeatsCount
+	6,  // 6: checkrepublic.offers.SearchOffersResponse.free_kilometer_range:type_name -> checkrepublic.offers.HistogramRange
+	8,  // 7: checkrepublic.offers.SearchOffersResponse.vollkasko_count:type_name -> checkrepublic.offers.VollkaskoCount
+	10, // 8: checkrepublic.offers.SearchOffersResponse.meta:type_name -> checkrepublic.offers.PageMeta
+	14, // 9: checkrepublic.offers.Region.sub_regions:type_name -> checkrepublic.offers.Region
+	1,  // 10: checkrepublic.offers.Offers.CreateOffers:input_type -> checkrepublic.offers.CreateOffersRequest
+	4,  // 11: checkrepublic.offers.Offers.SearchOffers:input_type -> checkrepublic.offers.SearchOffersRequest
+	11, // 12: checkrepublic.offers.Offers.DeleteOffers:input_type -> checkrepublic.offers.DeleteOffersRequest
+	13, // 13: checkrepublic.offers.Offers.GetRegion:input_type -> checkrepublic.offers.GetRegionRequest
+	3,  // 14: checkrepublic.offers.Offers.CreateOffers:output_type -> checkrepublic.offers.CreateOffersResponse
+	9,  // 15: checkrepublic.offers.Offers.SearchOffers:output_type -> checkrepublic.offers.SearchOffersResponse
+	12, // 16: checkrepublic.offers.Offers.DeleteOffers:output_type -> checkrepublic.offers.DeleteOffersResponse
+	14, // 17: checkrepublic.offers.Offers.GetRegion:output_type -> checkrepublic.offers.Region
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_offers_proto_init() }
+func file_offers_proto_init() {
+	if File_offers_proto != nil {
+		return
+	}
+	file_offers_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_offers_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_offers_proto_goTypes,
+		DependencyIndexes: file_offers_proto_depIdxs,
+		MessageInfos:      file_offers_proto_msgTypes,
+	}.Build()
+	File_offers_proto = out.File
+	file_offers_proto_rawDesc = nil
+	file_offers_proto_goTypes = nil
+	file_offers_proto_depIdxs = nil
+}
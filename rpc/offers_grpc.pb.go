@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: offers.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Offers_CreateOffers_FullMethodName = "/checkrepublic.offers.Offers/CreateOffers"
+	Offers_SearchOffers_FullMethodName = "/checkrepublic.offers.Offers/SearchOffers"
+	Offers_DeleteOffers_FullMethodName = "/checkrepublic.offers.Offers/DeleteOffers"
+	Offers_GetRegion_FullMethodName    = "/checkrepublic.offers.Offers/GetRegion"
+)
+
+// OffersClient is the client API for Offers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Offers mirrors the HTTP offers API
+type OffersClient interface {
+	// CreateOffers streams batches of offers into the database
+	CreateOffers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateOffersRequest, CreateOffersResponse], error)
+	// SearchOffers is GET /api/offers
+	SearchOffers(ctx context.Context, in *SearchOffersRequest, opts ...grpc.CallOption) (*SearchOffersResponse, error)
+	// DeleteOffers is DELETE /api/offers
+	DeleteOffers(ctx context.Context, in *DeleteOffersRequest, opts ...grpc.CallOption) (*DeleteOffersResponse, error)
+	// GetRegion returns a region with all of its subregions
+	GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*Region, error)
+}
+
+type offersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOffersClient(cc grpc.ClientConnInterface) OffersClient {
+	return &offersClient{cc}
+}
+
+func (c *offersClient) CreateOffers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateOffersRequest, CreateOffersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Offers_ServiceDesc.Streams[0], Offers_CreateOffers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateOffersRequest, CreateOffersResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Offers_CreateOffersClient = grpc.ClientStreamingClient[CreateOffersRequest, CreateOffersResponse]
+
+func (c *offersClient) SearchOffers(ctx context.Context, in *SearchOffersRequest, opts ...grpc.CallOption) (*SearchOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchOffersResponse)
+	err := c.cc.Invoke(ctx, Offers_SearchOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offersClient) DeleteOffers(ctx context.Context, in *DeleteOffersRequest, opts ...grpc.CallOption) (*DeleteOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOffersResponse)
+	err := c.cc.Invoke(ctx, Offers_DeleteOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offersClient) GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*Region, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Region)
+	err := c.cc.Invoke(ctx, Offers_GetRegion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OffersServer is the server API for Offers service.
+// All implementations must embed UnimplementedOffersServer
+// for forward compatibility.
+//
+// Offers mirrors the HTTP offers API
+type OffersServer interface {
+	// CreateOffers streams batches of offers into the database
+	CreateOffers(grpc.ClientStreamingServer[CreateOffersRequest, CreateOffersResponse]) error
+	// SearchOffers is GET /api/offers
+	SearchOffers(context.Context, *SearchOffersRequest) (*SearchOffersResponse, error)
+	// DeleteOffers is DELETE /api/offers
+	DeleteOffers(context.Context, *DeleteOffersRequest) (*DeleteOffersResponse, error)
+	// GetRegion returns a region with all of its subregions
+	GetRegion(context.Context, *GetRegionRequest) (*Region, error)
+	mustEmbedUnimplementedOffersServer()
+}
+
+// UnimplementedOffersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOffersServer struct{}
+
+func (UnimplementedOffersServer) CreateOffers(grpc.ClientStreamingServer[CreateOffersRequest, CreateOffersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateOffers not implemented")
+}
+func (UnimplementedOffersServer) SearchOffers(context.Context, *SearchOffersRequest) (*SearchOffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchOffers not implemented")
+}
+func (UnimplementedOffersServer) DeleteOffers(context.Context, *DeleteOffersRequest) (*DeleteOffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOffers not implemented")
+}
+func (UnimplementedOffersServer) GetRegion(context.Context, *GetRegionRequest) (*Region, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegion not implemented")
+}
+func (UnimplementedOffersServer) mustEmbedUnimplementedOffersServer() {}
+func (UnimplementedOffersServer) testEmbeddedByValue()                {}
+
+// UnsafeOffersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OffersServer will
+// result in compilation errors.
+type UnsafeOffersServer interface {
+	mustEmbedUnimplementedOffersServer()
+}
+
+func RegisterOffersServer(s grpc.ServiceRegistrar, srv OffersServer) {
+	// If the following call pancis, it indicates UnimplementedOffersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Offers_ServiceDesc, srv)
+}
+
+func _Offers_CreateOffers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OffersServer).CreateOffers(&grpc.GenericServerStream[CreateOffersRequest, CreateOffersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Offers_CreateOffersServer = grpc.ClientStreamingServer[CreateOffersRequest, CreateOffersResponse]
+
+func _Offers_SearchOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OffersServer).SearchOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Offers_SearchOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OffersServer).SearchOffers(ctx, req.(*SearchOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Offers_DeleteOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OffersServer).DeleteOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Offers_DeleteOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OffersServer).DeleteOffers(ctx, req.(*DeleteOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Offers_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OffersServer).GetRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Offers_GetRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OffersServer).GetRegion(ctx, req.(*GetRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Offers_ServiceDesc is the grpc.ServiceDesc for Offers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Offers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "checkrepublic.offers.Offers",
+	HandlerType: (*OffersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchOffers",
+			Handler:    _Offers_SearchOffers_Handler,
+		},
+		{
+			MethodName: "DeleteOffers",
+			Handler:    _Offers_DeleteOffers_Handler,
+		},
+		{
+			MethodName: "GetRegion",
+			Handler:    _Offers_GetRegion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateOffers",
+			Handler:       _Offers_CreateOffers_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "offers.proto",
+}
@@ -0,0 +1,165 @@
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative offers.proto
+
+package rpc
+
+import (
+	"check_republic/db"
+	"check_republic/models"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the Offers service on top of an offer database
+type Server struct {
+	UnimplementedOffersServer
+	DB db.OfferDatabase
+}
+
+func (s *Server) CreateOffers(stream Offers_CreateOffersServer) error {
+	ingester := models.NewIngester(10000, func(offers []*models.Offer) error {
+		return s.DB.CreateOffers(stream.Context(), offers...)
+	})
+
+	index := uint64(0)
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, o := range req.Offers {
+			index++
+			offer, err := fromProto(o)
+			if err := ingester.Add(index, offer, err); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+	if err := ingester.Flush(); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	report := ingester.Report
+	resp := &CreateOffersResponse{Received: report.Lines, Created: report.Created, Failed: report.Failed}
+	for _, e := range report.Errors {
+		resp.Errors = append(resp.Errors, &OfferError{Index: e.Line, Error: e.Error})
+	}
+	return stream.SendAndClose(resp)
+}
+
+func (s *Server) SearchOffers(ctx context.Context, req *SearchOffersRequest) (*SearchOffersResponse, error) {
+	dto, err := s.DB.GetFilteredOffers(ctx,
+		uint64(req.RegionId),
+		req.TimeRangeStart,
+		req.TimeRangeEnd,
+		req.NumberDays,
+		req.SortOrder,
+		req.Page,
+		req.PageSize,
+		models.HistogramOptions{Width: req.PriceRangeWidth},
+		models.HistogramOptions{Width: req.MinFreeKilometerWidth},
+		models.HistogramOptions{},
+		models.DateHistogramOptions{},
+		req.MinNumberSeats,
+		req.NumberSeats,
+		req.MinPrice,
+		req.MaxPrice,
+		req.CarTypes,
+		req.OnlyVollkasko,
+		req.MinFreeKilometer,
+		req.MinPricePerDay,
+		req.MaxPricePerDay,
+		false,
+		false,
+		nil,
+		nil)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &SearchOffersResponse{
+		CarTypeCounts:      dto.CarTypeCounts,
+		PriceRanges:        toProtoRanges(dto.PriceRanges),
+		FreeKilometerRange: toProtoRanges(dto.FreeKilometerRange),
+		Meta: &PageMeta{
+			Total:    dto.Meta.Total,
+			Page:     dto.Meta.Page,
+			PageSize: dto.Meta.PageSize,
+			HasNext:  dto.Meta.HasNext,
+		},
+	}
+	for _, o := range dto.Offers {
+		resp.Offers = append(resp.Offers, &SearchResultOffer{Id: o.ID, Data: *o.Data})
+	}
+	// All aggregations are computed, the checks only guard against nil
+	if dto.VollkaskoCount != nil {
+		resp.VollkaskoCount = &VollkaskoCount{TrueCount: dto.VollkaskoCount.TrueCount, FalseCount: dto.VollkaskoCount.FalseCount}
+	}
+	if dto.SeatsCount != nil {
+		for _, seats := range *dto.SeatsCount {
+			resp.SeatsCount = append(resp.SeatsCount, &SeatsCount{NumberSeats: seats.NumberSeats, Count: seats.Count})
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteOffers(ctx context.Context, req *DeleteOffersRequest) (*DeleteOffersResponse, error) {
+	if err := s.DB.DeleteAllOffers(ctx); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &DeleteOffersResponse{}, nil
+}
+
+func (s *Server) GetRegion(ctx context.Context, req *GetRegionRequest) (*Region, error) {
+	region, ok := models.RegionsByID[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown region %d", req.Id)
+	}
+	return toProtoRegion(region), nil
+}
+
+func fromProto(o *Offer) (*models.Offer, error) {
+	id, err := uuid.Parse(o.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Offer{
+		ID:                   id,
+		Data:                 o.Data,
+		MostSpecificRegionID: o.MostSpecificRegionId,
+		StartDate:            o.StartDate,
+		EndDate:              o.EndDate,
+		NumberSeats:          o.NumberSeats,
+		Price:                o.Price,
+		CarType:              o.CarType,
+		HasVollkasko:         o.HasVollkasko,
+		FreeKilometers:       o.FreeKilometers,
+	}, nil
+}
+
+func toProtoRanges(ranges *[]models.HistogramRange) []*HistogramRange {
+	if ranges == nil {
+		return nil
+	}
+	ret := make([]*HistogramRange, 0, len(*ranges))
+	for _, r := range *ranges {
+		ret = append(ret, &HistogramRange{Start: r.Start, End: r.End, Count: r.Count})
+	}
+	return ret
+}
+
+func toProtoRegion(region *models.Region) *Region {
+	ret := &Region{Id: region.Id, Name: region.Name}
+	for i := range region.SubRegions {
+		ret.SubRegions = append(ret.SubRegions, toProtoRegion(&region.SubRegions[i]))
+	}
+	return ret
+}